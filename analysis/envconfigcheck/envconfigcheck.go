@@ -0,0 +1,1030 @@
+// Package envconfigcheck defines an Analyzer which checks the configuration structs passed to envconfig.
+//
+// It finds the structs passed to envconfig.Init, InitWithPrefix, InitWithOptions, Load, MustLoad and NewWatcher
+// and reports:
+//   - tag tokens which envconfig would silently use as the key name, like "optinal" or "defualt=1m"
+//   - default values which can't be parsed into the field type, like default=1x on a time.Duration
+//   - fields with an unsupported type, like maps or interfaces
+//   - unexported fields when AllowUnexported is not used
+//   - fields which resolve to the same key
+//
+// The types with a parser registered with envconfig.RegisterParser in the package or one of its dependencies,
+// or added with WithParser, are not checked. Neither are the fields of the implementations of the interfaces
+// registered with envconfig.RegisterType, which depend on the values.
+package envconfigcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"net"
+	"net/netip"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/vrischmann/envconfig"
+	"github.com/vrischmann/envconfig/internal/editdistance"
+	"github.com/vrischmann/envconfig/internal/gotypesutil"
+	"github.com/vrischmann/envconfig/internal/lookup"
+	"github.com/vrischmann/envconfig/internal/tags"
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+const doc = `check the configuration structs passed to envconfig
+
+The envconfigcheck analyzer reports problems envconfig would only report at runtime, or not at all:
+unknown tag tokens, invalid default values, unsupported field types, unexported fields and
+fields resolving to the same key.`
+
+// Analyzer is the envconfigcheck analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:      "envconfigcheck",
+	Doc:       doc,
+	URL:       "https://pkg.go.dev/github.com/vrischmann/envconfig/analysis/envconfigcheck",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(registrations)},
+}
+
+// registrations is the fact of a package which calls envconfig.RegisterParser or envconfig.RegisterType:
+// the types with a parser and the interfaces with implementations it registers, as returned by types.TypeString.
+type registrations struct {
+	Parsers    []string
+	Interfaces []string
+}
+
+func (*registrations) AFact() {}
+
+func (f *registrations) String() string {
+	return fmt.Sprintf("registrations(parsers: %s; interfaces: %s)", strings.Join(f.Parsers, ", "), strings.Join(f.Interfaces, ", "))
+}
+
+const envconfigPath = "github.com/vrischmann/envconfig"
+
+// knownOptions are the tag tokens which are not a key name.
+var knownOptions = []string{"-", "optional", "default=", "name=", "names=", "deprecated=", "indexed", "json", "unit=", "layout=", "schemes=", "extendedbool", "encoding=", "enum=", "sep="}
+
+// options are the options of a call which matter to the checks.
+type options struct {
+	prefix string
+	// allowUnexported is true if AllowUnexported is used or if we can't know.
+	allowUnexported bool
+	// unknownKeys is true if a NameMapper is used or if we can't know: the keys of the fields are unknown.
+	unknownKeys bool
+	// decodeJSON is true if DecodeJSON is used: the fields of an unsupported type are decoded from JSON.
+	decodeJSON bool
+	// extendedBools is true if ExtendedBools is used or if a parent struct has the extendedbool option.
+	extendedBools bool
+	// parsers are the types of the parsers added with WithParser.
+	parsers []types.Type
+	// unknownParsers is true if Options.Parsers is used or if we can't know: any type may have a parser.
+	unknownParsers bool
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	c := &checker{
+		pass:     pass,
+		reported: make(map[string]bool),
+	}
+	c.registered, c.interfaces = registeredTypes(pass)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != envconfigPath {
+			return
+		}
+
+		var (
+			typ  types.Type
+			opts options
+		)
+
+		switch fn.Name() {
+		case "Init", "InitWithPrefix", "InitWithOptions":
+			if len(call.Args) == 0 {
+				return
+			}
+			typ = pass.TypesInfo.TypeOf(call.Args[0])
+
+			switch {
+			case fn.Name() == "InitWithPrefix" && len(call.Args) == 2:
+				opts.prefix, _ = c.constString(call.Args[1])
+			case fn.Name() == "InitWithOptions" && len(call.Args) == 2:
+				opts = c.optionsFromLiteral(call.Args[1])
+			}
+
+		case "NewWatcher":
+			typ = typeArg(pass, call)
+			if len(call.Args) == 1 {
+				opts = c.optionsFromLiteral(call.Args[0])
+			}
+
+		case "Load", "MustLoad":
+			typ = typeArg(pass, call)
+			opts = c.optionsFromFunctional(call.Args)
+
+		default:
+			return
+		}
+
+		st := configStruct(typ)
+		if st == nil {
+			return
+		}
+
+		c.keys = make(map[string]string)
+		c.parsers = opts.parsers
+		c.checkStruct(call, st, "", opts)
+	})
+
+	return nil, nil
+}
+
+// registeredTypes returns the types registered with envconfig.RegisterParser and the interfaces registered with
+// envconfig.RegisterType in the package and its dependencies. It exports the ones of the package as a fact.
+func registeredTypes(pass *analysis.Pass) (parsers, interfaces map[string]bool) {
+	parsers, interfaces = make(map[string]bool), make(map[string]bool)
+	for _, fact := range pass.AllPackageFacts() {
+		if f, ok := fact.Fact.(*registrations); ok {
+			for _, t := range f.Parsers {
+				parsers[t] = true
+			}
+			for _, t := range f.Interfaces {
+				interfaces[t] = true
+			}
+		}
+	}
+
+	var own registrations
+	for id, inst := range pass.TypesInfo.Instances {
+		fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != envconfigPath {
+			continue
+		}
+
+		t := types.TypeString(inst.TypeArgs.At(0), nil)
+		switch {
+		case fn.Name() == "RegisterParser" && !parsers[t]:
+			parsers[t] = true
+			own.Parsers = append(own.Parsers, t)
+		case fn.Name() == "RegisterType" && !interfaces[t]:
+			interfaces[t] = true
+			own.Interfaces = append(own.Interfaces, t)
+		}
+	}
+	if len(own.Parsers) > 0 || len(own.Interfaces) > 0 {
+		sort.Strings(own.Parsers)
+		sort.Strings(own.Interfaces)
+		pass.ExportPackageFact(&own)
+	}
+
+	return parsers, interfaces
+}
+
+// typeArg returns the first type argument of a call to a generic function.
+func typeArg(pass *analysis.Pass, call *ast.CallExpr) types.Type {
+	fun := ast.Unparen(call.Fun)
+	switch e := fun.(type) {
+	case *ast.IndexExpr:
+		fun = e.X
+	case *ast.IndexListExpr:
+		fun = e.X
+	}
+
+	var id *ast.Ident
+	switch e := fun.(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return nil
+	}
+
+	inst, ok := pass.TypesInfo.Instances[id]
+	if !ok || inst.TypeArgs.Len() == 0 {
+		return nil
+	}
+
+	return inst.TypeArgs.At(0)
+}
+
+// configStruct returns the struct which envconfig populates for a value of type t, if any.
+func configStruct(t types.Type) *types.Struct {
+	if t == nil {
+		return nil
+	}
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = ptr.Elem()
+	}
+
+	st, _ := t.Underlying().(*types.Struct)
+	return st
+}
+
+type checker struct {
+	pass     *analysis.Pass
+	reported map[string]bool
+	// registered are the types registered with envconfig.RegisterParser, as returned by types.TypeString.
+	registered map[string]bool
+	// interfaces are the interfaces registered with envconfig.RegisterType, as returned by types.TypeString.
+	interfaces map[string]bool
+
+	// keys maps each key to the field using it, for the struct currently checked.
+	keys map[string]string
+	// parsers are the types of the parsers added with WithParser, for the struct currently checked.
+	parsers []types.Type
+}
+
+// hasParser returns true if t has a registered parser.
+func (c *checker) hasParser(t types.Type) bool {
+	if c.registered[types.TypeString(t, nil)] {
+		return true
+	}
+	for _, p := range c.parsers {
+		if types.Identical(p, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// isParsed returns true if the values of a field of type t are read by a registered parser: t, the type t points to
+// or the element type of t if it's a slice have one.
+func (c *checker) isParsed(t types.Type) bool {
+	for {
+		if c.hasParser(t) {
+			return true
+		}
+		switch u := t.Underlying().(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		default:
+			return false
+		}
+	}
+}
+
+func (c *checker) constString(e ast.Expr) (string, bool) {
+	tv, ok := c.pass.TypesInfo.Types[e]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+func (c *checker) constBool(e ast.Expr) (bool, bool) {
+	tv, ok := c.pass.TypesInfo.Types[e]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.Bool {
+		return false, false
+	}
+	return constant.BoolVal(tv.Value), true
+}
+
+// optionsFromLiteral extracts the options from an envconfig.Options composite literal.
+func (c *checker) optionsFromLiteral(e ast.Expr) options {
+	lit, ok := ast.Unparen(e).(*ast.CompositeLit)
+	if !ok {
+		return options{allowUnexported: true, unknownKeys: true, unknownParsers: true}
+	}
+
+	var opts options
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return options{allowUnexported: true, unknownKeys: true, unknownParsers: true}
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		switch key.Name {
+		case "Prefix":
+			opts.prefix, _ = c.constString(kv.Value)
+		case "AllowUnexported":
+			v, ok := c.constBool(kv.Value)
+			opts.allowUnexported = v || !ok
+		case "NameMapper":
+			opts.unknownKeys = true
+		case "DecodeJSON":
+			v, ok := c.constBool(kv.Value)
+			opts.decodeJSON = v || !ok
+		case "ExtendedBools":
+			v, ok := c.constBool(kv.Value)
+			opts.extendedBools = v || !ok
+		case "Parsers":
+			// a struct type with a parser may have unexported fields
+			opts.unknownParsers = true
+			opts.allowUnexported = true
+		}
+	}
+
+	return opts
+}
+
+// optionsFromFunctional extracts the options from the arguments of Load and MustLoad.
+func (c *checker) optionsFromFunctional(args []ast.Expr) options {
+	var opts options
+	for _, arg := range args {
+		call, ok := ast.Unparen(arg).(*ast.CallExpr)
+		if !ok {
+			return options{prefix: opts.prefix, allowUnexported: true, unknownKeys: true, unknownParsers: true}
+		}
+		fn, ok := typeutil.Callee(c.pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != envconfigPath {
+			return options{prefix: opts.prefix, allowUnexported: true, unknownKeys: true, unknownParsers: true}
+		}
+
+		switch fn.Name() {
+		case "WithPrefix":
+			if len(call.Args) == 1 {
+				opts.prefix, _ = c.constString(call.Args[0])
+			}
+		case "AllowUnexported":
+			opts.allowUnexported = true
+		case "WithNameMapper":
+			opts.unknownKeys = true
+		case "DecodeJSON":
+			opts.decodeJSON = true
+		case "ExtendedBools":
+			opts.extendedBools = true
+		case "WithParser":
+			if t := typeArg(c.pass, call); t != nil {
+				opts.parsers = append(opts.parsers, t)
+			}
+		case "WithOptions":
+			if len(call.Args) == 1 {
+				opts = c.optionsFromLiteral(call.Args[0])
+			}
+		}
+	}
+	return opts
+}
+
+// report reports a problem with a field. If the field is declared in the package being analyzed the problem
+// is reported on the field, otherwise it's reported on the call.
+func (c *checker) report(call *ast.CallExpr, field *types.Var, format string, args ...interface{}) {
+	pos := call.Pos()
+	if c.inPackage(field.Pos()) {
+		pos = field.Pos()
+	}
+
+	msg := fmt.Sprintf(format, args...)
+
+	key := fmt.Sprintf("%d:%s", pos, msg)
+	if c.reported[key] {
+		return
+	}
+	c.reported[key] = true
+
+	c.pass.Reportf(pos, "%s", msg)
+}
+
+func (c *checker) inPackage(pos token.Pos) bool {
+	for _, f := range c.pass.Files {
+		if f.FileStart <= pos && pos <= f.FileEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStruct checks all fields of a struct. It follows what envconfig's readStruct does.
+//
+// name is the field chain of the struct, without the prefix.
+func (c *checker) checkStruct(call *ast.CallExpr, st *types.Struct, name string, opts options) {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		rawTag := reflect.StructTag(st.Tag(i)).Get("envconfig")
+		// the invalid options are reported by checkTag
+		tag, _ := tags.Parse(rawTag)
+		fieldName := combineName(name, field.Name())
+
+		c.checkTag(call, field, fieldName, rawTag)
+
+		if tag.Skip {
+			continue
+		}
+		if !field.Exported() {
+			if !opts.allowUnexported {
+				c.report(call, field, "field %s is unexported: use AllowUnexported or skip it with envconfig:\"-\"", fieldName)
+			}
+			continue
+		}
+
+		if tag.JSON {
+			c.checkJSONField(call, field, fieldName, tag, opts)
+			continue
+		}
+
+		if c.isParsed(field.Type()) {
+			// the values can't be checked without running the parser
+			c.checkKeys(call, field, fieldName, tag, opts)
+			continue
+		}
+		if c.interfaces[types.TypeString(field.Type(), nil)] {
+			// the fields depend on the implementation named by the key of the type
+			c.checkKeys(call, field, combineName(fieldName, "Type"), tag, opts)
+			continue
+		}
+
+		fieldType := field.Type()
+		t := fieldType
+		if !gotypesutil.IsUnmarshaler(fieldType) {
+			for {
+				ptr, ok := t.Underlying().(*types.Pointer)
+				if !ok || gotypesutil.IsValueType(t) {
+					break
+				}
+				t = ptr.Elem()
+			}
+		}
+
+		if st, ok := t.Underlying().(*types.Struct); ok && !gotypesutil.IsValueType(t) {
+			subOpts := opts
+			subOpts.extendedBools = opts.extendedBools || tag.ExtendedBool
+			c.checkStruct(call, st, fieldName, subOpts)
+			continue
+		}
+
+		if tag.Sep != "" {
+			c.checkCollectionField(call, field, fieldName, t, tag, opts)
+			continue
+		}
+
+		if tag.Indexed {
+			slice, ok := t.Underlying().(*types.Slice)
+			if !ok || gotypesutil.IsValueType(t) {
+				hint := ""
+				if tag.MaxIndex == 0 {
+					hint = keyNameHint("indexed")
+				}
+				c.report(call, field, "the indexed option can't be used on field %s which is not a slice%s", fieldName, hint)
+				continue
+			}
+			if st := indexedStruct(slice.Elem()); st != nil {
+				// the keys of the elements depend on the values, they can't be checked
+				elemOpts := opts
+				elemOpts.unknownKeys = true
+				c.checkStruct(call, st, fieldName+".0", elemOpts)
+				continue
+			}
+		}
+
+		if err := c.checkFieldType(t); err != nil {
+			if opts.decodeJSON {
+				c.checkJSONField(call, field, fieldName, tag, opts)
+				continue
+			}
+			if opts.unknownParsers {
+				c.checkKeys(call, field, fieldName, tag, opts)
+				continue
+			}
+			c.report(call, field, "field %s has an unsupported type: %v", fieldName, err)
+			continue
+		}
+
+		if tag.Unit != "" && !isInteger(t) {
+			c.report(call, field, "the unit option can't be used on field %s which is not an integer", fieldName)
+			continue
+		}
+		if tag.Layout != "" && !isTime(t) {
+			c.report(call, field, "the layout option can't be used on field %s which is not a time.Time", fieldName)
+			continue
+		}
+		if len(tag.Schemes) > 0 && !isURL(t) {
+			c.report(call, field, "the schemes option can't be used on field %s which is not an url.URL", fieldName)
+			continue
+		}
+		if tag.Encoding != "" && !gotypesutil.IsByteSlice(t) && !gotypesutil.IsByteArray(valueType(t)) {
+			c.report(call, field, "the encoding option can't be used on field %s which is not a []byte or a byte array", fieldName)
+			continue
+		}
+		if len(tag.Enum) > 0 {
+			et := valueType(t)
+			if !gotypesutil.IsEnumKind(et) || gotypesutil.IsUnmarshaler(et) {
+				c.report(call, field, "the enum option can't be used on field %s which is not a string or an integer", fieldName)
+				continue
+			}
+			if err := checkEnumValues(et, tag.Enum); err != nil {
+				c.report(call, field, "invalid enum option for field %s: %v", fieldName, err)
+				continue
+			}
+		}
+		if tag.ExtendedBool && !gotypesutil.IsBasic(valueType(t), types.IsBoolean) {
+			c.report(call, field, "the extendedbool option can't be used on field %s which is not a bool%s", fieldName, keyNameHint("extendedbool"))
+			continue
+		}
+		tag.ExtendedBool = tag.ExtendedBool || opts.extendedBools
+
+		if tag.Default != "" {
+			if err := c.checkDefault(t, tag.Default, tag); err != nil {
+				c.report(call, field, "invalid default value %q for field %s: %v", tag.Default, fieldName, err)
+			}
+		}
+
+		c.checkKeys(call, field, fieldName, tag, opts)
+	}
+}
+
+// checkCollectionField checks a nested collection field read with the separators of the sep option.
+func (c *checker) checkCollectionField(call *ast.CallExpr, field *types.Var, fieldName string, t types.Type, tag tags.Tag, opts options) {
+	depth := gotypesutil.CollectionDepth(t)
+	if depth == 0 {
+		c.report(call, field, "the sep option can't be used on field %s which is not a slice or a map", fieldName)
+		return
+	}
+	if n := utf8.RuneCountInString(tag.Sep); n != depth {
+		c.report(call, field, "the sep option %q of field %s has %d separators but its type %s has %d levels", tag.Sep, fieldName, n, t, depth)
+		return
+	}
+	if err := c.checkCollectionType(t); err != nil {
+		c.report(call, field, "field %s has an unsupported type: %v", fieldName, err)
+		return
+	}
+
+	tag.ExtendedBool = tag.ExtendedBool || opts.extendedBools
+
+	if tag.Default != "" {
+		if err := c.checkCollectionDefault(t, tag.Default, 0, tag); err != nil {
+			c.report(call, field, "invalid default value %q for field %s: %v", tag.Default, fieldName, err)
+		}
+	}
+
+	c.checkKeys(call, field, fieldName, tag, opts)
+}
+
+// checkJSONField checks a field decoded from JSON. Its type can't be checked without running the code.
+func (c *checker) checkJSONField(call *ast.CallExpr, field *types.Var, fieldName string, tag tags.Tag, opts options) {
+	t := field.Type()
+	for ptr, ok := t.Underlying().(*types.Pointer); ok; ptr, ok = t.Underlying().(*types.Pointer) {
+		t = ptr.Elem()
+	}
+	if _, ok := t.Underlying().(*types.Basic); ok && tag.JSON {
+		// a JSON string or number is unlikely, json was probably meant as the key name
+		c.report(call, field, "field %s of type %s is decoded from JSON with the json option%s", fieldName, field.Type(), keyNameHint("json"))
+	}
+	if tag.Default != "" && !json.Valid([]byte(tag.Default)) {
+		c.report(call, field, "invalid default value %q for field %s: it is not valid JSON", tag.Default, fieldName)
+	}
+
+	c.checkKeys(call, field, fieldName, tag, opts)
+}
+
+// checkKeys reports the fields which resolve to a key already used by another field.
+func (c *checker) checkKeys(call *ast.CallExpr, field *types.Var, fieldName string, tag tags.Tag, opts options) {
+	if opts.unknownKeys {
+		return
+	}
+	for _, key := range lookup.Keys(tag.CustomNames(), func() []string {
+		return envconfig.FlexibleNames.Keys(opts.prefix, strings.Split(fieldName, "."))
+	}) {
+		if other, ok := c.keys[key]; ok && other != fieldName {
+			c.report(call, field, "field %s resolves to the key %s which is also used by field %s", fieldName, key, other)
+			break
+		}
+		c.keys[key] = fieldName
+	}
+}
+
+// checkTag reports the tag tokens which envconfig would use as the key name while they look like an option.
+func (c *checker) checkTag(call *ast.CallExpr, field *types.Var, fieldName, rawTag string) {
+	if rawTag == "" {
+		return
+	}
+
+	var name, names string
+	for _, tok := range strings.Split(rawTag, ",") {
+		if tok == "-" || tok == "optional" || strings.HasPrefix(tok, "default=") {
+			continue
+		}
+		if tok == "indexed" || tok == "json" || tok == "extendedbool" {
+			continue
+		}
+		if strings.HasPrefix(tok, "layout=") {
+			continue
+		}
+		if strings.HasPrefix(tok, "encoding=") {
+			if _, err := values.ParseBytes("", strings.TrimPrefix(tok, "encoding=")); err != nil {
+				c.report(call, field, "%v in the envconfig tag of field %s", err, fieldName)
+			}
+			continue
+		}
+		if strings.HasPrefix(tok, "schemes=") {
+			for _, scheme := range strings.Split(strings.TrimPrefix(tok, "schemes="), "|") {
+				if scheme == "" {
+					c.report(call, field, "empty scheme in the envconfig tag of field %s", fieldName)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(tok, "enum=") {
+			for _, value := range strings.Split(strings.TrimPrefix(tok, "enum="), "|") {
+				if name, _, _ := strings.Cut(value, ":"); name == "" {
+					c.report(call, field, "empty enum value in the envconfig tag of field %s", fieldName)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(tok, "sep=") {
+			c.checkSeparators(call, field, fieldName, strings.TrimPrefix(tok, "sep="))
+			continue
+		}
+		if strings.HasPrefix(tok, "unit=") {
+			if unit := strings.TrimPrefix(tok, "unit="); unit != "bytes" {
+				c.report(call, field, "unknown unit %q in the envconfig tag of field %s: the only unit is \"bytes\"", unit, fieldName)
+			}
+			continue
+		}
+		if strings.HasPrefix(tok, "indexed=") {
+			if n, err := strconv.Atoi(strings.TrimPrefix(tok, "indexed=")); err != nil || n <= 0 {
+				c.report(call, field, "invalid maximum index in %q in the envconfig tag of field %s", tok, fieldName)
+			}
+			continue
+		}
+		if strings.HasPrefix(tok, "deprecated=") {
+			for _, key := range strings.Split(strings.TrimPrefix(tok, "deprecated="), "|") {
+				if key == "" || !isValidKey(key) {
+					c.report(call, field, "invalid deprecated key %q in the envconfig tag of field %s", key, fieldName)
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(tok, "names=") {
+			for _, key := range strings.Split(strings.TrimPrefix(tok, "names="), "|") {
+				if key == "" || !isValidKey(key) {
+					c.report(call, field, "invalid key %q in the envconfig tag of field %s", key, fieldName)
+				}
+			}
+			if name != "" {
+				c.report(call, field, "key name %q in the envconfig tag of field %s is overridden by %q", name, fieldName, tok)
+			}
+			names = tok
+			continue
+		}
+		tok = strings.TrimPrefix(tok, "name=")
+
+		switch {
+		case strings.Contains(tok, "="):
+			c.report(call, field, "unknown option %q in the envconfig tag of field %s: it is used as the key name", tok, fieldName)
+		case suggestOption(tok) != "":
+			c.report(call, field, "%q in the envconfig tag of field %s is used as the key name, did you mean %q?", tok, fieldName, suggestOption(tok))
+		case tok == "":
+			c.report(call, field, "empty token in the envconfig tag of field %s", fieldName)
+		case !isValidKey(tok):
+			c.report(call, field, "%q in the envconfig tag of field %s is used as the key name: tag values can't contain commas", tok, fieldName)
+		case names != "":
+			c.report(call, field, "key name %q in the envconfig tag of field %s is overridden by %q", tok, fieldName, names)
+		case name != "":
+			c.report(call, field, "key name %q in the envconfig tag of field %s is overridden by %q", name, fieldName, tok)
+		}
+
+		name = tok
+	}
+}
+
+// checkSeparators reports the separators of the sep option which can't be used.
+func (c *checker) checkSeparators(call *ast.CallExpr, field *types.Var, fieldName, seps string) {
+	if seps == "" {
+		c.report(call, field, "empty sep option in the envconfig tag of field %s, a comma can't be a separator", fieldName)
+		return
+	}
+	for i, r := range seps {
+		if strings.ContainsRune(`{}"\`, r) {
+			c.report(call, field, "invalid separator %q in the envconfig tag of field %s: braces, quotes and backslashes can't be separators", r, fieldName)
+			return
+		}
+		if strings.ContainsRune(seps[:i], r) {
+			c.report(call, field, "duplicate separator %q in the envconfig tag of field %s", r, fieldName)
+			return
+		}
+	}
+}
+
+// keyNameHint returns the hint added to the reports about the options which were key names before being options,
+// like indexed, json or extendedbool: a tag like envconfig:"json" may be meant as the key name.
+func keyNameHint(option string) string {
+	return fmt.Sprintf(": use name=%s if it's the key name", option)
+}
+
+// suggestOption returns the option which is close to tok, if any.
+func suggestOption(tok string) string {
+	lower := strings.ToLower(tok)
+	for _, opt := range knownOptions {
+		if len(opt) < 3 {
+			continue
+		}
+		if editdistance.Levenshtein(lower, strings.TrimSuffix(opt, "=")) <= 2 {
+			return opt
+		}
+	}
+	return ""
+}
+
+// isValidKey returns true if s looks like a key: it must not start with a digit and only contain
+// letters, digits, underscores, dots and dashes.
+func isValidKey(s string) bool {
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r == '.', r == '-':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// indexedStruct returns the struct read for each element of type t of an indexed slice, or nil.
+func indexedStruct(t types.Type) *types.Struct {
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok || gotypesutil.IsValueType(t) {
+			break
+		}
+		t = ptr.Elem()
+	}
+	if gotypesutil.IsValueType(t) {
+		return nil
+	}
+	st, _ := t.Underlying().(*types.Struct)
+	return st
+}
+
+// checkFieldType checks that envconfig can read a field of type t.
+func (c *checker) checkFieldType(t types.Type) error {
+	if slice, ok := t.Underlying().(*types.Slice); ok && !gotypesutil.IsValueType(t) {
+		if gotypesutil.IsByteSlice(t) {
+			return nil
+		}
+		return c.checkValueType(slice.Elem())
+	}
+	return c.checkValueType(t)
+}
+
+// checkCollectionType checks that envconfig can read a nested collection of type t with the sep option: its map
+// keys and its values must be supported.
+func (c *checker) checkCollectionType(t types.Type) error {
+	if gotypesutil.CollectionDepth(t) == 0 {
+		return c.checkValueType(t)
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Map:
+		if err := c.checkValueType(u.Key()); err != nil {
+			return err
+		}
+		return c.checkCollectionType(u.Elem())
+	case *types.Slice:
+		return c.checkCollectionType(u.Elem())
+	default:
+		return fmt.Errorf("%s is not supported", t)
+	}
+}
+
+// checkValueType checks that envconfig can parse a value of type t. It follows what envconfig's parseValue does.
+func (c *checker) checkValueType(t types.Type) error {
+	if c.hasParser(t) || gotypesutil.IsValueType(t) || gotypesutil.IsDuration(t) || gotypesutil.IsByteArray(t) {
+		return nil
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if u.Info()&(types.IsBoolean|types.IsInteger|types.IsFloat|types.IsString) == 0 || u.Kind() == types.Uintptr {
+			return fmt.Errorf("%s is not supported", t)
+		}
+		return nil
+
+	case *types.Pointer:
+		return c.checkValueType(u.Elem())
+
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if err := c.checkValueType(u.Field(i).Type()); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%s is not supported", t)
+	}
+}
+
+// isInteger returns true if t, the element type of t if it's a slice or the type t points to is an integer.
+func isInteger(t types.Type) bool {
+	if slice, ok := t.Underlying().(*types.Slice); ok && !gotypesutil.IsValueType(t) {
+		t = slice.Elem()
+	}
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok || gotypesutil.IsUnmarshaler(t) {
+			break
+		}
+		t = ptr.Elem()
+	}
+	return gotypesutil.IsBasic(t, types.IsInteger) && !gotypesutil.IsUnmarshaler(t) && !gotypesutil.IsDuration(t)
+}
+
+// isTime returns true if t, the element type of t if it's a slice or the type t points to is time.Time.
+func isTime(t types.Type) bool {
+	return gotypesutil.IsTime(valueType(t))
+}
+
+// isURL is like isTime for url.URL.
+func isURL(t types.Type) bool {
+	return gotypesutil.IsNamed(valueType(t), "net/url", "URL")
+}
+
+// valueType returns the element type of t if it's a slice, then the type it points to if it's a pointer.
+func valueType(t types.Type) types.Type {
+	if slice, ok := t.Underlying().(*types.Slice); ok && !gotypesutil.IsValueType(t) {
+		t = slice.Elem()
+	}
+	if ptr, ok := t.Underlying().(*types.Pointer); ok && !gotypesutil.IsValueType(t) {
+		t = ptr.Elem()
+	}
+	return t
+}
+
+// checkEnumValues checks that the integer values of the enum option fit in t.
+func checkEnumValues(t types.Type, enumValues []string) error {
+	for _, value := range enumValues {
+		name, val, ok := strings.Cut(value, ":")
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch {
+		case gotypesutil.IsBasic(t, types.IsString):
+			err = fmt.Errorf("%q has a value but %s is not an integer", name, t)
+		case gotypesutil.IsBasic(t, types.IsUnsigned):
+			_, err = values.ParseUint(val, gotypesutil.BitSize(t))
+		default:
+			_, err = values.ParseInt(val, gotypesutil.BitSize(t))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDefault checks that the default value def can be parsed into a field of type t with the options of tag.
+func (c *checker) checkDefault(t types.Type, def string, tag tags.Tag) error {
+	if slice, ok := t.Underlying().(*types.Slice); ok && !gotypesutil.IsValueType(t) {
+		if gotypesutil.IsByteSlice(t) {
+			_, err := values.ParseBytes(def, tag.Encoding)
+			return err
+		}
+
+		tokens, err := values.SplitSlice(def, true)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			if err := c.checkDefaultValue(slice.Elem(), token, tag); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return c.checkDefaultValue(t, def, tag)
+}
+
+// checkCollectionDefault is like checkDefault for the level level of a nested collection of type t.
+func (c *checker) checkCollectionDefault(t types.Type, str string, level int, tag tags.Tag) error {
+	if gotypesutil.CollectionDepth(t) == 0 {
+		return c.checkDefaultValue(t, str, tag)
+	}
+
+	split := values.SplitCollection
+	if _, ok := t.Underlying().(*types.Map); ok {
+		split = values.SplitMap
+	}
+	tokens, err := split(str, tag.Sep, level)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		switch u := t.Underlying().(type) {
+		case *types.Map:
+			k, v, err := values.SplitMapEntry(token)
+			if err == nil {
+				err = c.checkDefaultValue(u.Key(), k, tag)
+			}
+			if err == nil {
+				err = c.checkCollectionDefault(u.Elem(), v, level+1, tag)
+			}
+			if err != nil {
+				return err
+			}
+		case *types.Slice:
+			if err := c.checkCollectionDefault(u.Elem(), token, level+1, tag); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *checker) checkDefaultValue(t types.Type, str string, tag tags.Tag) error {
+	var err error
+
+	switch {
+	case c.hasParser(t), gotypesutil.IsUnmarshaler(t):
+		// can't know without running the code
+	case gotypesutil.IsDuration(t):
+		_, err = values.ParseDuration(str)
+	case len(tag.Enum) > 0 && gotypesutil.IsEnumKind(t):
+		_, err = values.ParseEnum(str, tag.Enum)
+	case gotypesutil.IsEnum(t):
+		// the values are only known at runtime
+	case gotypesutil.IsTime(t):
+		_, err = values.ParseTime(str, tag.Layout)
+	case gotypesutil.IsLocation(t):
+		_, err = values.ParseLocation(str)
+	case gotypesutil.IsNamed(t, "net/url", "URL"):
+		_, err = values.ParseURL(str, tag.Schemes...)
+	case gotypesutil.IsNamed(t, "net", "IP"):
+		_, err = values.ParseIP(str)
+	case gotypesutil.IsNamed(t, "net", "IPNet"):
+		_, err = values.ParseIPNet(str)
+	case gotypesutil.IsNamed(t, "net", "HardwareAddr"):
+		_, err = net.ParseMAC(str)
+	case gotypesutil.IsNamed(t, "net/netip", "Addr"):
+		_, err = netip.ParseAddr(str)
+	case gotypesutil.IsNamed(t, "net/netip", "Prefix"):
+		_, err = netip.ParsePrefix(str)
+	case gotypesutil.IsNamed(t, "net/netip", "AddrPort"):
+		_, err = netip.ParseAddrPort(str)
+	case gotypesutil.IsByteArray(t):
+		_, err = values.ParseByteArray(str, tag.Encoding, int(t.Underlying().(*types.Array).Len()))
+	case gotypesutil.IsBasic(t, types.IsBoolean) && tag.ExtendedBool:
+		_, err = values.ParseExtendedBool(str)
+	case gotypesutil.IsBasic(t, types.IsBoolean):
+		_, err = values.ParseBool(str)
+	case gotypesutil.IsBasic(t, types.IsUnsigned) && tag.Unit == "bytes":
+		_, err = values.ParseUintBytes(str, gotypesutil.BitSize(t))
+	case gotypesutil.IsBasic(t, types.IsInteger) && tag.Unit == "bytes":
+		_, err = values.ParseIntBytes(str, gotypesutil.BitSize(t))
+	case gotypesutil.IsBasic(t, types.IsUnsigned):
+		_, err = values.ParseUint(str, gotypesutil.BitSize(t))
+	case gotypesutil.IsBasic(t, types.IsInteger):
+		_, err = values.ParseInt(str, gotypesutil.BitSize(t))
+	case gotypesutil.IsBasic(t, types.IsFloat):
+		_, err = values.ParseFloat(str, gotypesutil.BitSize(t))
+	default:
+		switch u := t.Underlying().(type) {
+		case *types.Pointer:
+			err = c.checkDefaultValue(u.Elem(), str, tag)
+		case *types.Struct:
+			if len(str) < 2 || str[0] != '{' || str[len(str)-1] != '}' {
+				return fmt.Errorf("struct value %q must be surrounded by { and }", str)
+			}
+			var tokens []string
+			tokens, err = values.SplitStruct(str, true, u.NumFields())
+			for i := 0; err == nil && i < len(tokens); i++ {
+				err = c.checkDefaultValue(u.Field(i).Type(), tokens[i], tag)
+			}
+		}
+	}
+
+	return err
+}
+
+func combineName(parentName, name string) string {
+	if parentName == "" {
+		return name
+	}
+
+	return parentName + "." + name
+}
@@ -0,0 +1,14 @@
+package envconfigcheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/vrischmann/envconfig/analysis/envconfigcheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, envconfigcheck.Analyzer, "a", "b")
+}
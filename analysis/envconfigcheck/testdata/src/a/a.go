@@ -0,0 +1,280 @@
+package a // want package:`registrations\(parsers: a\.Money; interfaces: a\.Backend\)`
+
+import (
+	"net"
+	"net/netip"
+	"net/url"
+	"time"
+
+	"github.com/vrischmann/envconfig"
+)
+
+type unmarshaled string
+
+func (u *unmarshaled) Unmarshal(s string) error { return nil }
+
+type Tags struct {
+	Name     string        `envconfig:"optinal"`     // want `"optinal" in the envconfig tag of field Name is used as the key name, did you mean "optional"\?`
+	Timeout  time.Duration `envconfig:"defualt=1m"`  // want `unknown option "defualt=1m" in the envconfig tag of field Timeout: it is used as the key name`
+	Ports    []int         `envconfig:"default=1,2"` // want `"2" in the envconfig tag of field Ports is used as the key name: tag values can't contain commas`
+	Addr     string        `envconfig:"addr,myAddr"` // want `key name "addr" in the envconfig tag of field Addr is overridden by "myAddr"`
+	Custom   string        `envconfig:"myCustom,optional,default=foo"`
+	Internal string        `envconfig:"-"`
+	Renamed  string        `envconfig:"name=DATABASE_URL,deprecated=DB_URL|DB_URI"`
+	Old      string        `envconfig:"deprecated=OLD_KEY|"` // want `invalid deprecated key "" in the envconfig tag of field Old`
+	DB       string        `envconfig:"names=DATABASE_URL1|DB_URL1|auto"`
+	DB2      string        `envconfig:"names=DATABASE_URL2|,optional"` // want `invalid key "" in the envconfig tag of field DB2`
+	DB3      string        `envconfig:"names=DATABASE_URL3,DB_URL3"`   // want `key name "DB_URL3" in the envconfig tag of field DB3 is overridden by "names=DATABASE_URL3"`
+	Shards   []struct {
+		Name  string
+		Hosts []string
+		Bad   map[string]int // want `field Shards.0.Bad has an unsupported type: map\[string\]int is not supported`
+	} `envconfig:"indexed"`
+	Hosts      []string `envconfig:"indexed=10"`
+	BadIndexed string   `envconfig:"indexed"`     // want `the indexed option can't be used on field BadIndexed which is not a slice: use name=indexed if it's the key name`
+	BadMax     []string `envconfig:"indexed=-1"`  // want `invalid maximum index in "indexed=-1" in the envconfig tag of field BadMax`
+	BadMaxWord []string `envconfig:"indexed=ten"` // want `invalid maximum index in "indexed=ten" in the envconfig tag of field BadMaxWord`
+	BadMaxType string   `envconfig:"indexed=3"`   // want `the indexed option can't be used on field BadMaxType which is not a slice$`
+}
+
+type Defaults struct {
+	Timeout time.Duration `envconfig:"default=1x"`   // want `invalid default value "1x" for field Timeout: time: unknown unit "x" in duration "1x"`
+	Port    int           `envconfig:"default=http"` // want `invalid default value "http" for field Port: strconv.ParseInt: parsing "http": invalid syntax`
+	Ports   []uint        `envconfig:"default=1;-2"` // want `invalid default value "1;-2" for field Ports: strconv.ParseUint: parsing "-2": invalid syntax`
+	Shards  []struct {    // want `invalid default value "{foo;1};{bar;x}" for field Shards: strconv.ParseInt: parsing "x": invalid syntax`
+		Name string
+		ID   int
+	} `envconfig:"default={foo;1};{bar;x}"`
+	Small int8            `envconfig:"default=128"` // want `invalid default value "128" for field Small: strconv.ParseInt: parsing "128": value out of range`
+	Perm  uint32          `envconfig:"default=0o644"`
+	OK    []time.Duration `envconfig:"default=1s;2m"`
+	Mode  unmarshaled     `envconfig:"default=anything"`
+}
+
+type Unsupported struct {
+	Map   map[string]int // want `field Map has an unsupported type: map\[string\]int is not supported`
+	Iface interface{}    // want `field Iface has an unsupported type: interface\{\} is not supported`
+	Chans []chan int     // want `field Chans has an unsupported type: chan int is not supported`
+	Ptr   *int
+}
+
+type JSON struct {
+	Routes  map[string][]string `envconfig:"json"`
+	Limits  map[string]int      `envconfig:"json,default={\"max\":10}"`
+	Bad     []string            `envconfig:"json,default=[a]"` // want `invalid default value "\[a\]" for field Bad: it is not valid JSON`
+	Primary *struct {
+		Name string
+	} `envconfig:"json"`
+	Token *string `envconfig:"json"` // want `field Token of type \*string is decoded from JSON with the json option: use name=json if it's the key name`
+}
+
+// Decoded has fields of unsupported types decoded from JSON with DecodeJSON.
+type Decoded struct {
+	Map      map[string]int
+	Matrix   [][]int `envconfig:"default=[[1]"` // want `invalid default value "\[\[1\]" for field Matrix: it is not valid JSON`
+	MyMatrix [][]int `envconfig:"MATRIX"`       // want `field MyMatrix resolves to the key MATRIX which is also used by field Matrix`
+}
+
+type Sizes struct {
+	Cache   int64    `envconfig:"unit=bytes,default=64MiB"`
+	Limits  []uint16 `envconfig:"unit=bytes,default=1kB;64KiB"` // want `invalid default value "1kB;64KiB" for field Limits: byte size "64KiB" is out of range`
+	Timeout uint32   `envconfig:"unit=seconds"`                 // want `unknown unit "seconds" in the envconfig tag of field Timeout: the only unit is "bytes"`
+	Name    string   `envconfig:"unit=bytes"`                   // want `the unit option can't be used on field Name which is not an integer`
+}
+
+type Times struct {
+	Cutover  time.Time
+	Window   *time.Time     `envconfig:"layout=2006-01-02 15:04,default=2024-03-02 04:00"`
+	Holidays []time.Time    `envconfig:"layout=DateOnly,default=2024-12-25;2025-13-01"` // want `invalid default value "2024-12-25;2025-13-01" for field Holidays: parsing time "2025-13-01": month out of range`
+	Start    time.Time      `envconfig:"default=2024-01-01"`                            // want `invalid default value "2024-01-01" for field Start: parsing time "2024-01-01" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "T"`
+	Zone     *time.Location `envconfig:"default=Mars/Olympus"`                          // want `invalid default value "Mars/Olympus" for field Zone: unknown time zone Mars/Olympus`
+	Timeout  time.Duration  `envconfig:"layout=unix"`                                   // want `the layout option can't be used on field Timeout which is not a time.Time`
+	Spans    []struct {
+		From time.Time
+		Zone *time.Location
+	}
+}
+
+type Network struct {
+	Endpoint url.URL
+	Proxy    *url.URL         `envconfig:"schemes=http|https,default=socks5://proxy"` // want `invalid default value "socks5://proxy" for field Proxy: scheme "socks5" of URL "socks5://proxy" is not allowed, use one of http, https`
+	Mirrors  []*url.URL       `envconfig:"schemes=https|"`                            // want `empty scheme in the envconfig tag of field Mirrors`
+	DNS      []net.IP         `envconfig:"default=1.1.1.1;1.1.1"`                     // want `invalid default value "1.1.1.1;1.1.1" for field DNS: invalid IP address "1.1.1"`
+	Allowed  []net.IPNet      `envconfig:"default=10.0.0.0/8"`
+	MAC      net.HardwareAddr `envconfig:"optional"`
+	Addr     netip.Addr       `envconfig:"default=localhost"` // want `invalid default value "localhost" for field Addr: ParseAddr\("localhost"\): unable to parse IP`
+	Peers    []netip.AddrPort `envconfig:"indexed"`
+	Name     string           `envconfig:"schemes=http"` // want `the schemes option can't be used on field Name which is not an url.URL`
+}
+
+type Bools struct {
+	Debug   bool `envconfig:"extendedbool,default=on"`
+	Strict  bool `envconfig:"default=on"` // want `invalid default value "on" for field Strict: strconv.ParseBool: parsing "on": invalid syntax`
+	Feature struct {
+		Enabled bool   `envconfig:"default=yes"`
+		Flags   []bool `envconfig:"default=y;maybe"` // want `invalid default value "y;maybe" for field Feature.Flags: invalid boolean "maybe", use one of true, false, 1, 0, t, f, yes, no, y, n, on, off, enabled, disabled`
+	} `envconfig:"extendedbool"`
+	Name string `envconfig:"extendedbool"` // want `the extendedbool option can't be used on field Name which is not a bool: use name=extendedbool if it's the key name`
+}
+
+// Extended has the extended bools of ExtendedBools.
+type Extended struct {
+	Debug bool `envconfig:"default=enabled"`
+}
+
+type Bytes struct {
+	Std  []byte    `envconfig:"encoding=base64,default=Rk9P"`
+	Hex  []byte    `envconfig:"encoding=hex,default=xyz"`  // want `invalid default value "xyz" for field Hex: invalid hex value: encoding/hex: invalid byte: U\+0078 'x'`
+	B32  []byte    `envconfig:"encoding=base32"`           // want `unknown encoding "base32", use one of base64, base64url, base64raw, hex or raw in the envconfig tag of field B32`
+	Key  [4]byte   `envconfig:"encoding=hex,default=0001"` // want `invalid default value "0001" for field Key: decoded value has 2 bytes, expected 4`
+	Keys [][2]byte `envconfig:"encoding=hex,default=0102;0304"`
+	Ints [4]int    // want `field Ints has an unsupported type: \[4\]int is not supported`
+	Name string    `envconfig:"encoding=hex"` // want `the encoding option can't be used on field Name which is not a \[\]byte or a byte array`
+}
+
+type Unexported struct {
+	Name    string
+	private string // want `field private is unexported: use AllowUnexported or skip it with envconfig:"-"`
+	skipped string `envconfig:"-"`
+}
+
+type Duplicates struct {
+	Cassandra struct {
+		SSLCert string
+	}
+	CassandraSSLCert string // want `field CassandraSSLCert resolves to the key CASSANDRA_SSL_CERT which is also used by field Cassandra.SSLCert`
+	Name             string `envconfig:"MY_NAME"`
+	MyName           string // want `field MyName resolves to the key MY_NAME which is also used by field Name`
+}
+
+// Mapped would have duplicates with the default NameMapper.
+type Mapped struct {
+	Cassandra struct {
+		SSLCert string
+	}
+	CassandraSSLCert string
+}
+
+type Clean struct {
+	Name string
+	Log  *struct {
+		Path string
+	}
+}
+
+// Money is read with a registered parser even though it has unexported fields.
+type Money struct {
+	cents int64
+}
+
+func parseMoney(s string) (Money, error) { return Money{}, nil }
+
+func init() {
+	envconfig.RegisterParser(parseMoney)
+}
+
+type point struct {
+	x, y int // want `field Origin.x is unexported: use AllowUnexported or skip it with envconfig:"-"` `field Origin.y is unexported: use AllowUnexported or skip it with envconfig:"-"`
+}
+
+func parsePoint(s string) (point, error) { return point{}, nil }
+
+type Parsed struct {
+	Price    Money `envconfig:"default=free"`
+	Discount *Money
+	Prices   []Money
+	Products []struct {
+		Name  string
+		Price Money
+	} `envconfig:"default={book;free}"`
+	Origin point
+}
+
+type Points struct {
+	Origin point
+}
+
+type level int
+
+func (level) EnumValues() []string { return []string{"debug", "info"} }
+
+type Enums struct {
+	Level   level         `envconfig:"default=trace"`
+	Levels  []level       `envconfig:"enum=low|high,default=high;low"`
+	Color   string        `envconfig:"enum=red|green,default=blue"` // want `invalid default value "blue" for field Color: invalid value "blue", use one of red, green`
+	Small   int8          `envconfig:"enum=a:1|b:300"`              // want `invalid enum option for field Small: strconv.ParseInt: parsing "300": value out of range`
+	Name    string        `envconfig:"enum=a:1"`                    // want `invalid enum option for field Name: "a" has a value but string is not an integer`
+	Empty   string        `envconfig:"enum=a||b"`                   // want `empty enum value in the envconfig tag of field Empty`
+	Timeout time.Duration `envconfig:"enum=short|long"`             // want `the enum option can't be used on field Timeout which is not a string or an integer`
+}
+
+type Collections struct {
+	Matrix  [][]int            `envconfig:"sep=|;,default=1;2|3"`
+	Weights []map[string]int   `envconfig:"sep=|;,default=cpu:2|disk"` // want `invalid default value "cpu:2\|disk" for field Weights: map entry "disk" must be written key:value`
+	Routes  map[level][]string `envconfig:"sep=;|,default=info:a|b"`
+	Grid    [][]float64        `envconfig:"sep=|;,default=1;x"` // want `invalid default value "1;x" for field Grid: strconv.ParseFloat: parsing "x": invalid syntax`
+	Flat    []int              `envconfig:"sep=|;"`             // want `the sep option "\|;" of field Flat has 2 separators but its type \[\]int has 1 levels`
+	Nested  [][]int            // want `field Nested has an unsupported type: \[\]int is not supported`
+	Port    int                `envconfig:"sep=;"`  // want `the sep option can't be used on field Port which is not a slice or a map`
+	Chans   [][]chan int       `envconfig:"sep=|;"` // want `field Chans has an unsupported type: chan int is not supported`
+	Empty   []int              `envconfig:"sep="`   // want `empty sep option in the envconfig tag of field Empty, a comma can't be a separator`
+	Braces  [][]int            `envconfig:"sep={;"` // want `invalid separator '\{' in the envconfig tag of field Braces: braces, quotes and backslashes can't be separators`
+	Twice   [][]int            `envconfig:"sep=;;"` // want `duplicate separator ';' in the envconfig tag of field Twice`
+}
+
+type Backend interface {
+	Open() error
+}
+
+type Disk struct {
+	Path string
+}
+
+func (d *Disk) Open() error { return nil }
+
+func init() {
+	envconfig.RegisterType[Backend]("disk", &Disk{})
+}
+
+type Plugins struct {
+	Storage Backend                   `envconfig:"default=disk"`
+	Cache   interface{ Get() string } // want `field Cache has an unsupported type: interface\{Get\(\) string\} is not supported`
+}
+
+func calls() {
+	var tags Tags
+	_ = envconfig.Init(&tags)
+
+	_, _ = envconfig.Load[Defaults]()
+	_ = envconfig.MustLoad[*Unsupported]()
+
+	var unexported Unexported
+	_ = envconfig.InitWithPrefix(&unexported, "APP")
+	_ = envconfig.InitWithOptions(&unexported, envconfig.Options{AllowUnexported: true})
+	_, _ = envconfig.Load[Unexported](envconfig.AllowUnexported())
+
+	_, _ = envconfig.NewWatcher[Duplicates](envconfig.Options{AllOptional: true})
+	_, _ = envconfig.Load[Mapped](envconfig.WithNameMapper(envconfig.ScreamingSnakeNames))
+
+	_, _ = envconfig.Load[JSON]()
+	_, _ = envconfig.Load[Sizes]()
+	_, _ = envconfig.Load[Times]()
+	_, _ = envconfig.Load[Network]()
+	_, _ = envconfig.Load[Bools]()
+	_, _ = envconfig.Load[Bytes]()
+	_, _ = envconfig.Load[Extended](envconfig.ExtendedBools())
+	_ = envconfig.InitWithOptions(&Extended{}, envconfig.Options{ExtendedBools: true})
+	_, _ = envconfig.Load[Decoded](envconfig.DecodeJSON())
+	_ = envconfig.InitWithOptions(&Decoded{}, envconfig.Options{DecodeJSON: true})
+
+	_, _ = envconfig.Load[Enums]()
+	_, _ = envconfig.Load[Collections]()
+	_, _ = envconfig.Load[Plugins]()
+	_, _ = envconfig.Load[Parsed](envconfig.WithParser(parsePoint))
+	_, _ = envconfig.Load[Points]()
+	_ = envconfig.InitWithOptions(&Points{}, envconfig.Options{Parsers: nil})
+	_ = envconfig.InitWithOptions(&Unsupported{}, envconfig.Options{Parsers: nil})
+
+	var clean *Clean
+	_ = envconfig.Init(&clean)
+}
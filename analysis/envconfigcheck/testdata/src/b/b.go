@@ -0,0 +1,23 @@
+package b
+
+import (
+	"a"
+
+	"github.com/vrischmann/envconfig"
+)
+
+func calls() {
+	var conf a.Unexported
+	_ = envconfig.Init(&conf) // want `field private is unexported: use AllowUnexported or skip it with envconfig:"-"`
+
+	opts := envconfig.Options{}
+	_ = envconfig.InitWithOptions(&conf, opts)
+
+	// the parser of a.Money and the implementations of a.Backend are registered in package a
+	var prices struct {
+		Price   a.Money
+		Prices  []*a.Money
+		Storage a.Backend
+	}
+	_ = envconfig.Init(&prices)
+}
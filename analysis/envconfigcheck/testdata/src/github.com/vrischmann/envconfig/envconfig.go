@@ -0,0 +1,43 @@
+// Package envconfig is a stub of the real package for the analyzer tests.
+package envconfig
+
+import "reflect"
+
+type Options struct {
+	Prefix          string
+	AllOptional     bool
+	LeaveNil        bool
+	AllowUnexported bool
+	NameMapper      NameMapper
+	DecodeJSON      bool
+	ExtendedBools   bool
+	Parsers         map[reflect.Type]func(string) (any, error)
+}
+
+type NameMapper interface {
+	Keys(path []string) []string
+}
+
+var ScreamingSnakeNames NameMapper
+
+type Option func(*Options)
+
+func Init(conf interface{}) error                          { return nil }
+func InitWithPrefix(conf interface{}, prefix string) error { return nil }
+func InitWithOptions(conf interface{}, opts Options) error { return nil }
+func Load[T any](opts ...Option) (T, error)                { var t T; return t, nil }
+func MustLoad[T any](opts ...Option) T                     { var t T; return t }
+func WithPrefix(prefix string) Option                      { return nil }
+func AllowUnexported() Option                              { return nil }
+func WithOptions(opts Options) Option                      { return nil }
+func WithNameMapper(m NameMapper) Option                   { return nil }
+func DecodeJSON() Option                                   { return nil }
+func ExtendedBools() Option                                { return nil }
+
+func RegisterParser[T any](parse func(string) (T, error))    {}
+func RegisterType[I any](name string, impl I)                {}
+func WithParser[T any](parse func(string) (T, error)) Option { return nil }
+
+type Watcher[T any] struct{}
+
+func NewWatcher[T any](opts Options) (*Watcher[T], error) { return nil, nil }
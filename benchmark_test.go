@@ -0,0 +1,76 @@
+package envconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vrischmann/envconfig"
+)
+
+type benchConfig struct {
+	Name    string
+	Port    int
+	Timeout time.Duration
+	Debug   bool `envconfig:"optional"`
+	MySQL   struct {
+		Host     string
+		Port     int `envconfig:"default=3306"`
+		Database struct {
+			User     string
+			Password string
+			Name     string
+		}
+	}
+	Cassandra struct {
+		SSLCert string
+		SSLKey  string
+	}
+	Hosts  []string
+	Shards []struct {
+		Name string
+		ID   int
+	}
+}
+
+var benchSource = envconfig.MapSource{
+	"NAME":                    "foobar",
+	"PORT":                    "8080",
+	"TIMEOUT":                 "10s",
+	"MYSQL_HOST":              "localhost",
+	"MYSQL_DATABASE_USER":     "root",
+	"MYSQL_DATABASE_PASSWORD": "secret",
+	"MYSQL_DATABASE_NAME":     "app",
+	"CASSANDRA_SSL_CERT":      "/etc/cassandra/ssl.crt",
+	"CASSANDRA_SSL_KEY":       "/etc/cassandra/ssl.key",
+	"HOSTS":                   "a,b,c",
+	"SHARDS":                  "{foo,1},{bar,2}",
+}
+
+func BenchmarkInit(b *testing.B) {
+	b.ReportAllocs()
+
+	opts := envconfig.Options{Source: benchSource}
+	for i := 0; i < b.N; i++ {
+		var conf benchConfig
+		if err := envconfig.InitWithOptions(&conf, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInitWithPrefix(b *testing.B) {
+	b.ReportAllocs()
+
+	src := envconfig.MapSource{}
+	for k, v := range benchSource {
+		src["APP_"+k] = v
+	}
+
+	opts := envconfig.Options{Prefix: "APP", Source: src}
+	for i := 0; i < b.N; i++ {
+		var conf benchConfig
+		if err := envconfig.InitWithOptions(&conf, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package envconfig
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+// ByteSize is a size in bytes which is read and printed in human form, like 512, 64KiB, 10MB or 1.5GiB.
+//
+// Both SI suffixes (kB, MB, GB, TB, PB, EB: powers of 1000) and IEC suffixes (KiB, MiB, GiB, TiB, PiB, EiB:
+// powers of 1024) are accepted, in any case. A value without suffix or with the B suffix is in bytes.
+//
+// Use the unit=bytes tag option to read an integer field the same way.
+type ByteSize uint64
+
+// Byte sizes.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1 << 10
+	MiB ByteSize = 1 << 20
+	GiB ByteSize = 1 << 30
+	TiB ByteSize = 1 << 40
+	PiB ByteSize = 1 << 50
+	EiB ByteSize = 1 << 60
+)
+
+// Unmarshal implements Unmarshaler.
+func (s *ByteSize) Unmarshal(str string) error {
+	v, err := values.ParseUintBytes(str, 64)
+	if err != nil {
+		return err
+	}
+	*s = ByteSize(v)
+	return nil
+}
+
+// String returns the size with the largest unit which gives at most three decimals, like 1.5GiB or 10MB.
+// The result can be read back by Unmarshal.
+func (s ByteSize) String() string {
+	if s == 0 {
+		return "0B"
+	}
+
+	for _, unit := range values.ByteUnits {
+		if uint64(s) < unit.Size {
+			continue
+		}
+
+		r := new(big.Rat).SetFrac(new(big.Int).SetUint64(uint64(s)), new(big.Int).SetUint64(unit.Size))
+		if !new(big.Rat).Mul(r, big.NewRat(1000, 1)).IsInt() {
+			continue
+		}
+
+		str := r.FloatString(3)
+		str = strings.TrimRight(strings.TrimRight(str, "0"), ".")
+
+		return str + unit.Suffix
+	}
+
+	return fmt.Sprintf("%dB", uint64(s))
+}
@@ -0,0 +1,137 @@
+package envconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+func TestByteSizeUnmarshal(t *testing.T) {
+	testCases := []struct {
+		str  string
+		size envconfig.ByteSize
+	}{
+		{"512", 512},
+		{"512B", 512},
+		{"64KiB", 64 * 1024},
+		{"64kib", 64 * 1024},
+		{"10MB", 10 * 1000 * 1000},
+		{"10 MB", 10 * 1000 * 1000},
+		{"1.5GiB", 1536 * 1024 * 1024},
+		{"1kB", 1000},
+		{"1KB", 1000},
+		{"1_000_000", 1000000},
+		{"16EiB", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.str, func(t *testing.T) {
+			var size envconfig.ByteSize
+			err := size.Unmarshal(tc.str)
+			if tc.size == 0 {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.size, size)
+		})
+	}
+}
+
+func TestByteSizeUnmarshalErrors(t *testing.T) {
+	testCases := []struct {
+		str string
+		err string
+	}{
+		{"", `invalid byte size ""`},
+		{"MB", `invalid byte size "MB"`},
+		{"1.2.3MB", `invalid byte size "1.2.3MB"`},
+		{"-1MB", `invalid byte size "-1MB": it can't be negative`},
+		{"1.5B", `invalid byte size "1.5B": it is not a whole number of bytes`},
+		{"10Mb/s", `invalid byte size "10Mb/s": unknown unit "Mb/s", use one of B, kB, MB, GB, TB, PB, EB, KiB, MiB, GiB, TiB, PiB or EiB`},
+		{"20EB", `byte size "20EB" is out of range`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.str, func(t *testing.T) {
+			var size envconfig.ByteSize
+			err := size.Unmarshal(tc.str)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	testCases := []struct {
+		size envconfig.ByteSize
+		str  string
+	}{
+		{0, "0B"},
+		{512, "512B"},
+		{1000, "1kB"},
+		{1024, "1KiB"},
+		{1536, "1.5KiB"},
+		{10 * envconfig.MB, "10MB"},
+		{1536 * envconfig.MiB, "1.5GiB"},
+		{1234567, "1234.567kB"},
+		{1<<64 - 1, "18446744073709551.615kB"},
+		{999, "999B"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.str, func(t *testing.T) {
+			require.Equal(t, tc.str, tc.size.String())
+
+			var size envconfig.ByteSize
+			require.NoError(t, size.Unmarshal(tc.str))
+			require.Equal(t, tc.size, size)
+		})
+	}
+}
+
+func TestUnitBytes(t *testing.T) {
+	var conf struct {
+		Buffer envconfig.ByteSize
+		Cache  int64    `envconfig:"unit=bytes,default=64MiB"`
+		Upload uint32   `envconfig:"unit=bytes"`
+		Limits []uint64 `envconfig:"unit=bytes"`
+		Max    *int     `envconfig:"unit=bytes"`
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"BUFFER": "4KiB",
+			"UPLOAD": "10MB",
+			"LIMITS": "512,1kB,2KiB",
+			"MAX":    "-1",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 4*envconfig.KiB, conf.Buffer)
+	require.Equal(t, int64(64<<20), conf.Cache)
+	require.Equal(t, uint32(10000000), conf.Upload)
+	require.Equal(t, []uint64{512, 1000, 2048}, conf.Limits)
+	require.Equal(t, -1, *conf.Max)
+}
+
+func TestUnitBytesErrors(t *testing.T) {
+	var conf struct {
+		Upload uint32 `envconfig:"unit=bytes"`
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{"UPLOAD": "4GiB"},
+	})
+	require.EqualError(t, err, `envconfig: unable to parse value "4GiB" for possible keys [UPLOAD upload]. err=byte size "4GiB" is out of range`)
+
+	var conf2 struct {
+		Timeout uint32 `envconfig:"unit=seconds"`
+	}
+
+	err = envconfig.InitWithOptions(&conf2, envconfig.Options{
+		Source: envconfig.MapSource{"TIMEOUT": "10"},
+	})
+	require.EqualError(t, err, `envconfig: unable to parse value "10" for possible keys [TIMEOUT timeout]. err=unknown unit "seconds", the only unit is "bytes"`)
+}
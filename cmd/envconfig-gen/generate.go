@@ -0,0 +1,622 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vrischmann/envconfig"
+	"github.com/vrischmann/envconfig/internal/gotypesutil"
+	"github.com/vrischmann/envconfig/internal/lookup"
+	"github.com/vrischmann/envconfig/internal/tags"
+)
+
+const envconfigrtPath = "github.com/vrischmann/envconfig/envconfigrt"
+
+type generator struct {
+	pkg     *types.Package
+	prefix  string
+	imports map[string]string // path -> name
+	buf     bytes.Buffer
+	n       int // used to generate unique variable names
+
+	// elemPath is the expression of the path of the collection element being parsed, like Matrix[2][1], or
+	// empty outside of collections.
+	elemPath string
+}
+
+// generate returns the source of the loaders of the types typeNames in pkg.
+func generate(pkg *types.Package, typeNames []string, prefix string) ([]byte, error) {
+	g := &generator{
+		pkg:     pkg,
+		prefix:  prefix,
+		imports: map[string]string{envconfigrtPath: "envconfigrt"},
+	}
+
+	for _, name := range typeNames {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			return nil, fmt.Errorf("type %s not found in package %s", name, pkg.Path())
+		}
+		typeName, ok := obj.(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a type", name)
+		}
+		st, ok := typeName.Type().Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not a struct", name)
+		}
+
+		if err := g.generateLoader(name, st, prefix); err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by envconfig-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg.Name())
+
+	// standard library imports first, like goimports does
+	var stdPaths, paths []string
+	for path := range g.imports {
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			paths = append(paths, path)
+		} else {
+			stdPaths = append(stdPaths, path)
+		}
+	}
+	sort.Strings(stdPaths)
+	sort.Strings(paths)
+
+	fmt.Fprintf(&buf, "import (\n")
+	for _, path := range stdPaths {
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	if len(stdPaths) > 0 {
+		fmt.Fprintf(&buf, "\n")
+	}
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	fmt.Fprintf(&buf, ")\n")
+
+	buf.Write(g.buf.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) varName(prefix string) string {
+	g.n++
+	return fmt.Sprintf("%s%d", prefix, g.n)
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == g.pkg {
+			return ""
+		}
+		g.imports[p.Path()] = p.Name()
+		return p.Name()
+	})
+}
+
+func (g *generator) generateLoader(name string, st *types.Struct, prefix string) error {
+	g.printf("\n// Load%s creates a %s and populates it with the values returned by lookup,\n", name, name)
+	g.printf("// following the same rules as envconfig.Init.\n")
+	g.printf("func Load%s(lookup func(string) (string, bool)) (%s, error) {\n", name, name)
+	g.printf("var conf %s\n\n", name)
+	g.printf("err := func() error {\n")
+
+	if err := g.readStruct("conf", st, prefix, false, false); err != nil {
+		return err
+	}
+
+	g.printf("return nil\n")
+	g.printf("}()\n")
+	g.printf("if err != nil {\nreturn %s{}, err\n}\n\n", name)
+	g.printf("return conf, nil\n")
+	g.printf("}\n")
+
+	return nil
+}
+
+// readStruct generates the code to read all fields of a struct. It follows what envconfig's readStruct does.
+//
+// optional and extendedBool are inherited from the tags of the parent structs.
+func (g *generator) readStruct(expr string, st *types.Struct, name string, optional, extendedBool bool) error {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag, err := tags.Parse(reflect.StructTag(st.Tag(i)).Get("envconfig"))
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name(), err)
+		}
+
+		if tag.Skip {
+			continue
+		}
+		if !field.Exported() {
+			return fmt.Errorf("unexported field %s", field.Name())
+		}
+		if tag.Indexed {
+			// The keys of indexed slices depend on the values so they can't be generated.
+			return fmt.Errorf("field %s: indexed slices are not supported", field.Name())
+		}
+
+		fieldName := combineName(name, field.Name())
+		fieldOptional := optional || tag.Optional
+		tag.ExtendedBool = tag.ExtendedBool || extendedBool
+		fieldType := field.Type()
+
+		target := expr + "." + field.Name()
+		t := fieldType
+
+		if tag.JSON {
+			g.setJSONField(target, fieldType, fieldName, tag, fieldOptional)
+			continue
+		}
+
+		if !gotypesutil.IsUnmarshaler(fieldType) {
+			for {
+				ptr, ok := types.Unalias(t).(*types.Pointer)
+				if !ok || gotypesutil.IsValueType(t) {
+					break
+				}
+				g.printf("%s = new(%s)\n", target, g.typeString(ptr.Elem()))
+				target = "(*" + target + ")"
+				t = ptr.Elem()
+			}
+		}
+
+		if st, ok := t.Underlying().(*types.Struct); ok && !gotypesutil.IsValueType(t) {
+			err = g.readStruct(target, st, fieldName, fieldOptional, tag.ExtendedBool)
+		} else {
+			err = g.setField(target, t, fieldName, tag, fieldOptional)
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) setField(target string, t types.Type, name string, tag tags.Tag, optional bool) error {
+	keys := g.fieldKeys(name, tag)
+
+	slice, isSlice := t.Underlying().(*types.Slice)
+	isSlice = isSlice && !gotypesutil.IsValueType(t)
+
+	depth := gotypesutil.CollectionDepth(t)
+	isCollection := tag.Sep != ""
+	if isCollection && depth != utf8.RuneCountInString(tag.Sep) {
+		return fmt.Errorf("the sep option %q doesn't match the type %s, use one separator per level", tag.Sep, t)
+	}
+
+	usingDefault := "_"
+	switch {
+	case isCollection:
+		// only the structs of a collection are split with the separators of the slices
+		if leaf := collectionLeaf(t); gotypesutil.IsStruct(leaf) && !gotypesutil.IsValueType(leaf) {
+			usingDefault = "usingDefault"
+		}
+	case isSlice && !gotypesutil.IsByteSlice(t):
+		usingDefault = "usingDefault"
+	}
+
+	g.readValue(keys, name, tag, optional, usingDefault)
+
+	switch {
+	case isCollection:
+		if err := g.setCollection(target, t, "str", 0, name, nil, tag); err != nil {
+			return err
+		}
+
+	case isSlice && gotypesutil.IsByteSlice(t):
+		g.printf("v, err := envconfigrt.ParseBytes(str, %q)\n", tag.Encoding)
+		g.printf("if err != nil {\nreturn envconfigrt.WrapBytesParseError(str, keys, err)\n}\n")
+		g.printf("%s = v\n", target)
+
+	case isSlice:
+		tokens, s, el := g.varName("tokens"), g.varName("s"), g.varName("el")
+
+		g.printf("%s, err := envconfigrt.SplitSlice(str, usingDefault)\n", tokens)
+		g.printf("if err != nil {\nreturn envconfigrt.WrapParseError(str, keys, err)\n}\n")
+		g.printf("%s := make(%s, 0, len(%s))\n", s, g.typeString(t), tokens)
+		g.printf("for _, tok := range %s {\n", tokens)
+		g.printf("var %s %s\n", el, g.typeString(slice.Elem()))
+		if err := g.parseValue(el, slice.Elem(), "tok", tag); err != nil {
+			return err
+		}
+		g.printf("%s = append(%s, %s)\n", s, s, el)
+		g.printf("}\n")
+		g.printf("%s = %s\n", target, s)
+
+	default:
+		if err := g.parseValue(target, t, "str", tag); err != nil {
+			return err
+		}
+	}
+
+	g.printf("}\n")
+	g.printf("}\n\n")
+
+	return nil
+}
+
+// setCollection generates the code to set the collection target from str, level level of the separators of the
+// sep tag option. It follows what envconfig's setCollection does.
+//
+// pathFormat and pathArgs are the fmt.Sprintf arguments of the path of the collection in the errors.
+func (g *generator) setCollection(target string, t types.Type, str string, level int, pathFormat string, pathArgs []string, tag tags.Tag) error {
+	path := g.pathExpr(pathFormat, pathArgs)
+	tokens, tok := g.varName("tokens"), g.varName("tok")
+
+	split := "SplitCollection"
+	if _, ok := t.Underlying().(*types.Map); ok {
+		split = "SplitMap"
+	}
+	g.printf("%s, err := envconfigrt.%s(%s, %q, %d)\n", tokens, split, str, tag.Sep, level)
+	g.printf("if err != nil {\nreturn envconfigrt.WrapElementParseError(%s, %s, keys, err)\n}\n", str, path)
+
+	switch u := t.Underlying().(type) {
+	case *types.Map:
+		m, k, v, key, el := g.varName("m"), g.varName("k"), g.varName("v"), g.varName("key"), g.varName("el")
+		elemFormat, elemArgs := pathFormat+"[%s]", append(pathArgs[:len(pathArgs):len(pathArgs)], k)
+
+		g.printf("%s := make(%s, len(%s))\n", m, g.typeString(t), tokens)
+		g.printf("for _, %s := range %s {\n", tok, tokens)
+		g.printf("%s, %s, err := envconfigrt.SplitMapEntry(%s)\n", k, v, tok)
+		g.printf("if err != nil {\nreturn envconfigrt.WrapElementParseError(%s, %s, keys, err)\n}\n", tok, path)
+		g.printf("var %s %s\n", key, g.typeString(u.Key()))
+		if err := g.parseElement(key, u.Key(), k, -1, elemFormat, elemArgs, tag); err != nil {
+			return err
+		}
+		g.printf("var %s %s\n", el, g.typeString(u.Elem()))
+		if err := g.parseElement(el, u.Elem(), v, level+1, elemFormat, elemArgs, tag); err != nil {
+			return err
+		}
+		g.printf("%s[%s] = %s\n", m, key, el)
+		g.printf("}\n")
+		g.printf("%s = %s\n", target, m)
+
+	case *types.Slice:
+		s, i, el := g.varName("s"), g.varName("i"), g.varName("el")
+		elemFormat, elemArgs := pathFormat+"[%d]", append(pathArgs[:len(pathArgs):len(pathArgs)], i)
+
+		g.printf("%s := make(%s, 0, len(%s))\n", s, g.typeString(t), tokens)
+		g.printf("for %s, %s := range %s {\n", i, tok, tokens)
+		g.printf("var %s %s\n", el, g.typeString(u.Elem()))
+		if err := g.parseElement(el, u.Elem(), tok, level+1, elemFormat, elemArgs, tag); err != nil {
+			return err
+		}
+		g.printf("%s = append(%s, %s)\n", s, s, el)
+		g.printf("}\n")
+		g.printf("%s = %s\n", target, s)
+
+	default:
+		return fmt.Errorf("type %s not supported", t)
+	}
+
+	return nil
+}
+
+// parseElement generates the code to parse an element of a collection, which is a collection itself if level is
+// one of the levels of the separators.
+func (g *generator) parseElement(target string, t types.Type, str string, level int, pathFormat string, pathArgs []string, tag tags.Tag) error {
+	if level >= 0 && level < utf8.RuneCountInString(tag.Sep) {
+		return g.setCollection(target, t, str, level, pathFormat, pathArgs, tag)
+	}
+
+	g.elemPath = g.pathExpr(pathFormat, pathArgs)
+	defer func() { g.elemPath = "" }()
+
+	return g.parseValue(target, t, str, tag)
+}
+
+// pathExpr returns the expression of the path of a collection element.
+func (g *generator) pathExpr(format string, args []string) string {
+	if len(args) == 0 {
+		return fmt.Sprintf("%q", format)
+	}
+
+	g.imports["fmt"] = "fmt"
+	return fmt.Sprintf("fmt.Sprintf(%q, %s)", format, strings.Join(args, ", "))
+}
+
+// wrapParseError returns the expression of the error returned when str can't be parsed.
+func (g *generator) wrapParseError(str string) string {
+	if g.elemPath != "" {
+		return fmt.Sprintf("envconfigrt.WrapElementParseError(%s, %s, keys, err)", str, g.elemPath)
+	}
+	return fmt.Sprintf("envconfigrt.WrapParseError(%s, keys, err)", str)
+}
+
+// collectionLeaf returns the type of the values of the collection type t, like int for [][]int or Shard for
+// []map[string]*Shard.
+func collectionLeaf(t types.Type) types.Type {
+	for gotypesutil.CollectionDepth(t) > 0 {
+		switch u := t.Underlying().(type) {
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Map:
+			t = u.Elem()
+		}
+	}
+	for {
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok || gotypesutil.IsValueType(t) {
+			return t
+		}
+		t = ptr.Elem()
+	}
+}
+
+// readValue generates the code to read the value of a field in str. It opens two blocks which must be closed.
+func (g *generator) readValue(keys []string, name string, tag tags.Tag, optional bool, usingDefault string) {
+	var deprecated string
+	for _, key := range tag.Deprecated {
+		deprecated += fmt.Sprintf(", %q", key)
+	}
+
+	g.printf("// %s\n", name)
+	g.printf("{\n")
+	g.printf("keys := %#v\n", keys)
+	g.printf("str, %s, err := envconfigrt.ReadValue(lookup, keys, %q, %v%s)\n", usingDefault, tag.Default, optional, deprecated)
+	g.printf("if err != nil {\nreturn err\n}\n")
+	g.printf("if str != \"\" {\n")
+}
+
+// setJSONField generates the code to decode the JSON value of a field. It follows what envconfig's readJSON does.
+func (g *generator) setJSONField(target string, t types.Type, name string, tag tags.Tag, optional bool) {
+	g.imports["encoding/json"] = "json"
+
+	g.readValue(g.fieldKeys(name, tag), name, tag, optional, "_")
+	g.printf("var v %s\n", g.typeString(t))
+	g.printf("if err := json.Unmarshal([]byte(str), &v); err != nil {\n")
+	g.printf("return envconfigrt.WrapJSONError(%q, keys, err)\n", name)
+	g.printf("}\n")
+	g.printf("%s = v\n", target)
+	g.printf("}\n")
+	g.printf("}\n\n")
+}
+
+// parseValue generates the code to parse str into target. It follows what envconfig's parseValue does.
+//
+// The generated code returns an error so it must be inside a function returning an error.
+// tag is the tag of the field, for the options changing how values are parsed.
+func (g *generator) parseValue(target string, t types.Type, str string, tag tags.Tag) error {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok && !gotypesutil.IsValueType(t) {
+		g.printf("%s = new(%s)\n", target, g.typeString(ptr.Elem()))
+		return g.parseValue("(*"+target+")", ptr.Elem(), str, tag)
+	}
+
+	if gotypesutil.IsEnumKind(t) && !gotypesutil.IsUnmarshaler(t) {
+		switch {
+		case len(tag.Enum) > 0:
+			g.parseEnum(target, t, str, fmt.Sprintf("%#v", tag.Enum))
+			return nil
+		case gotypesutil.IsEnum(t):
+			g.parseEnum(target, t, str, fmt.Sprintf("new(%s).EnumValues()", g.typeString(t)))
+			return nil
+		}
+	}
+
+	if gotypesutil.IsBasic(t, types.IsString) && !gotypesutil.IsUnmarshaler(t) {
+		g.printf("%s = %s(%s)\n", target, g.typeString(t), str)
+		return nil
+	}
+
+	g.printf("if err := func() error {\n")
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		if gotypesutil.IsLocation(t) {
+			g.printf("v, err := envconfigrt.ParseLocation(%s)\n", str)
+			g.printf("if err != nil {\nreturn err\n}\n")
+			g.printf("%s = v\n", target)
+			g.printf("return nil\n")
+			break
+		}
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", target, target, g.typeString(u.Elem()))
+		g.printf("return %s.Unmarshal(%s)\n", target, str)
+
+	case *types.Array:
+		if gotypesutil.IsUnmarshaler(t) {
+			g.printf("return %s.Unmarshal(%s)\n", target, str)
+			break
+		}
+		if !gotypesutil.IsByteArray(t) {
+			return fmt.Errorf("type %s not supported", t)
+		}
+		g.printf("v, err := envconfigrt.ParseByteArray(%s, %q, %d)\n", str, tag.Encoding, u.Len())
+		g.printf("if err != nil {\nreturn err\n}\n")
+		g.printf("copy(%s[:], v)\n", target)
+		g.printf("return nil\n")
+
+	case *types.Map:
+		if !gotypesutil.IsUnmarshaler(t) {
+			return fmt.Errorf("kind map not supported")
+		}
+		g.printf("%s = make(%s)\n", target, g.typeString(t))
+		g.printf("return %s.Unmarshal(%s)\n", target, str)
+
+	case *types.Basic, *types.Struct, *types.Slice:
+		switch {
+		case gotypesutil.IsUnmarshaler(t):
+			g.printf("return %s.Unmarshal(%s)\n", target, str)
+
+		case gotypesutil.IsDuration(t):
+			g.parseScalar(target, t, "envconfigrt.ParseDuration("+str+")")
+
+		case gotypesutil.IsTime(t):
+			g.parseScalar(target, t, fmt.Sprintf("envconfigrt.ParseTime(%s, %q)", str, tag.Layout))
+
+		case gotypesutil.IsNamed(t, "net/url", "URL"):
+			var schemes string
+			for _, scheme := range tag.Schemes {
+				schemes += fmt.Sprintf(", %q", scheme)
+			}
+			g.parsePointer(target, fmt.Sprintf("envconfigrt.ParseURL(%s%s)", str, schemes))
+
+		case gotypesutil.IsNamed(t, "net", "IP"):
+			g.parseScalar(target, t, "envconfigrt.ParseIP("+str+")")
+
+		case gotypesutil.IsNamed(t, "net", "IPNet"):
+			g.parsePointer(target, "envconfigrt.ParseIPNet("+str+")")
+
+		case gotypesutil.IsNamed(t, "net", "HardwareAddr"):
+			g.imports["net"] = "net"
+			g.parseScalar(target, t, "net.ParseMAC("+str+")")
+
+		case gotypesutil.IsNamed(t, "net/netip", "Addr"), gotypesutil.IsNamed(t, "net/netip", "Prefix"), gotypesutil.IsNamed(t, "net/netip", "AddrPort"):
+			g.imports["net/netip"] = "netip"
+			g.parseScalar(target, t, fmt.Sprintf("netip.Parse%s(%s)", types.Unalias(t).(*types.Named).Obj().Name(), str))
+
+		case gotypesutil.IsBasic(t, types.IsBoolean):
+			if tag.ExtendedBool {
+				g.parseScalar(target, t, "envconfigrt.ParseExtendedBool("+str+")")
+			} else {
+				g.parseScalar(target, t, "envconfigrt.ParseBool("+str+")")
+			}
+
+		case gotypesutil.IsBasic(t, types.IsInteger) && gotypesutil.IsBasic(t, types.IsUnsigned):
+			if u.(*types.Basic).Kind() == types.Uintptr {
+				return fmt.Errorf("kind uintptr not supported")
+			}
+			call, err := parseIntegerCall("Uint", tag.Unit)
+			if err != nil {
+				return err
+			}
+			g.parseScalar(target, t, fmt.Sprintf("%s(%s, %d)", call, str, gotypesutil.BitSize(t)))
+
+		case gotypesutil.IsBasic(t, types.IsInteger):
+			call, err := parseIntegerCall("Int", tag.Unit)
+			if err != nil {
+				return err
+			}
+			g.parseScalar(target, t, fmt.Sprintf("%s(%s, %d)", call, str, gotypesutil.BitSize(t)))
+
+		case gotypesutil.IsBasic(t, types.IsFloat):
+			g.parseScalar(target, t, fmt.Sprintf("envconfigrt.ParseFloat(%s, %d)", str, gotypesutil.BitSize(t)))
+
+		case gotypesutil.IsStruct(t):
+			st := u.(*types.Struct)
+			tokens := g.varName("tokens")
+
+			g.printf("%s, err := envconfigrt.SplitStruct(%s, usingDefault, %d)\n", tokens, str, st.NumFields())
+			g.printf("if err != nil {\nreturn err\n}\n")
+			for i := 0; i < st.NumFields(); i++ {
+				field := st.Field(i)
+				if !field.Exported() {
+					return fmt.Errorf("unexported field %s", field.Name())
+				}
+				if err := g.parseValue(target+"."+field.Name(), field.Type(), fmt.Sprintf("%s[%d]", tokens, i), tag); err != nil {
+					return err
+				}
+			}
+			g.printf("return nil\n")
+
+		default:
+			return fmt.Errorf("type %s not supported", t)
+		}
+
+	default:
+		return fmt.Errorf("type %s not supported", t)
+	}
+
+	g.printf("}(); err != nil {\n")
+	g.printf("return %s\n", g.wrapParseError(str))
+	g.printf("}\n")
+
+	return nil
+}
+
+// parseEnum generates the code to parse str into target, which is restricted to values. It follows what
+// envconfig's parseEnumValue does.
+func (g *generator) parseEnum(target string, t types.Type, str, values string) {
+	g.printf("if err := func() error {\n")
+	if gotypesutil.IsBasic(t, types.IsString) {
+		g.printf("if _, err := envconfigrt.ParseEnum(%s, %s); err != nil {\nreturn err\n}\n", str, values)
+		g.printf("%s = %s(%s)\n", target, g.typeString(t), str)
+	} else {
+		g.imports["fmt"] = "fmt"
+
+		// the name of the type like reflect prints it, for the error
+		name := types.TypeString(t, func(p *types.Package) string { return p.Name() })
+
+		overflow := fmt.Sprintf("int64(%s) != v", target)
+		if gotypesutil.IsBasic(t, types.IsUnsigned) {
+			overflow = "v < 0 || " + overflow
+		}
+
+		g.printf("v, err := envconfigrt.ParseEnum(%s, %s)\n", str, values)
+		g.printf("if err != nil {\nreturn err\n}\n")
+		g.printf("%s = %s(v)\n", target, g.typeString(t))
+		g.printf("if %s {\nreturn fmt.Errorf(\"value %%d of %%q overflows %s\", v, %s)\n}\n", overflow, name, str)
+	}
+	g.printf("return nil\n")
+	g.printf("}(); err != nil {\n")
+	g.printf("return %s\n", g.wrapParseError(str))
+	g.printf("}\n")
+}
+
+// parseIntegerCall returns the function parsing an integer of the given kind, Int or Uint, in unit.
+func parseIntegerCall(kind, unit string) (string, error) {
+	switch unit {
+	case "":
+		return "envconfigrt.Parse" + kind, nil
+	case "bytes":
+		return "envconfigrt.Parse" + kind + "Bytes", nil
+	default:
+		return "", fmt.Errorf("unknown unit %q", unit)
+	}
+}
+
+// parsePointer is like parseScalar for the parse functions returning a pointer.
+func (g *generator) parsePointer(target string, call string) {
+	g.printf("v, err := %s\n", call)
+	g.printf("if err != nil {\nreturn err\n}\n")
+	g.printf("%s = *v\n", target)
+	g.printf("return nil\n")
+}
+
+func (g *generator) parseScalar(target string, t types.Type, call string) {
+	g.printf("v, err := %s\n", call)
+	g.printf("if err != nil {\nreturn err\n}\n")
+	g.printf("%s = %s(v)\n", target, g.typeString(t))
+	g.printf("return nil\n")
+}
+
+// fieldKeys returns the keys of the field chain name, like envconfig's makeAllPossibleKeys does.
+func (g *generator) fieldKeys(name string, tag tags.Tag) []string {
+	if g.prefix != "" {
+		name = strings.TrimPrefix(name, g.prefix+".")
+	}
+
+	return lookup.Keys(tag.CustomNames(), func() []string {
+		return envconfig.FlexibleNames.Keys(g.prefix, strings.Split(name, "."))
+	})
+}
+
+func combineName(parentName, name string) string {
+	if parentName == "" {
+		return name
+	}
+
+	return parentName + "." + name
+}
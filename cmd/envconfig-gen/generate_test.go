@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedConformanceCodeIsUpToDate(t *testing.T) {
+	dir := filepath.Join("internal", "conformance")
+
+	pkg, err := loadPackage(dir)
+	require.NoError(t, err)
+
+	src, err := generate(pkg.Types, []string{"Simple", "Nested", "Pointers", "Slices", "Defaults", "Renamed", "JSON", "Sizes", "Times", "Network", "Bools", "Bytes", "Enums", "Collections"}, "")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "zz_envconfig.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(data), string(src), "run go generate ./... in %s", dir)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	pkg, err := loadPackage(filepath.Join("internal", "conformance"))
+	require.NoError(t, err)
+
+	_, err = generate(pkg.Types, []string{"Unknown"}, "")
+	require.EqualError(t, err, "type Unknown not found in package github.com/vrischmann/envconfig/cmd/envconfig-gen/internal/conformance")
+
+	_, err = generate(pkg.Types, []string{"LogMode"}, "")
+	require.EqualError(t, err, "type LogMode is not a struct")
+
+	_, err = generate(pkg.Types, []string{"Indexed"}, "")
+	require.EqualError(t, err, "type Indexed: field Hosts: indexed slices are not supported")
+}
+
+func TestGeneratePrefix(t *testing.T) {
+	pkg, err := loadPackage(filepath.Join("internal", "conformance"))
+	require.NoError(t, err)
+
+	src, err := generate(pkg.Types, []string{"Shard"}, "APP")
+	require.NoError(t, err)
+	require.Contains(t, string(src), `keys := []string{"APP_NAME", "app_name"}`)
+}
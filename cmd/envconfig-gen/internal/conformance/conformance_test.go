@@ -0,0 +1,245 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+// check loads a T with both envconfig.InitWithOptions and the generated loader and checks they agree.
+// wantErr is true if loading fails, so that a case can't quietly go from a success to the same error.
+func check[T any](t *testing.T, load func(func(string) (string, bool)) (T, error), src envconfig.MapSource, wantErr bool) {
+	t.Helper()
+
+	var expected T
+	expectedErr := envconfig.InitWithOptions(&expected, envconfig.Options{Source: src})
+	if wantErr {
+		require.Error(t, expectedErr)
+	} else {
+		require.NoError(t, expectedErr)
+	}
+
+	actual, err := load(src.Lookup)
+	if expectedErr != nil {
+		require.EqualError(t, err, expectedErr.Error())
+		return
+	}
+
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func TestConformance(t *testing.T) {
+	testCases := []struct {
+		name    string
+		check   func(t *testing.T, src envconfig.MapSource, wantErr bool)
+		src     envconfig.MapSource
+		wantErr bool
+	}{
+		{"simple", checkSimple, envconfig.MapSource{
+			"NAME": "foobar", "PORT": "80", "LONG": "1600000000000000000", "VERSION": "2",
+			"DELTA": "0.02", "DELTAV": "400.20000000001", "DOIT": "true", "TIMEOUT": "1m",
+			"DATA": "Rk9PQkFS", "MODE": "stdout",
+		}, false},
+		{"simple lower case", checkSimple, envconfig.MapSource{
+			"name": "foobar", "port": "80", "long": "1", "version": "2",
+			"delta": "0.02", "deltav": "1", "doit": "false", "timeout": "1s",
+			"data": "", "DATA": "Rk9PQkFS", "mode": "file",
+		}, false},
+		{"simple missing key", checkSimple, envconfig.MapSource{"NAME": "foobar"}, true},
+		{"simple invalid int", checkSimple, envconfig.MapSource{"NAME": "foobar", "PORT": "foobar"}, true},
+		{"simple base prefixes", checkSimple, envconfig.MapSource{
+			"NAME": "foobar", "PORT": "0x50", "LONG": "1_000", "VERSION": "0b10",
+			"DELTA": "0.02", "DELTAV": "1", "DOIT": "true", "TIMEOUT": "1m",
+			"DATA": "Rk9PQkFS", "MODE": "stdout",
+		}, false},
+		{"simple overflow", checkSimple, envconfig.MapSource{"NAME": "foobar", "PORT": "80", "LONG": "1", "VERSION": "256"}, true},
+		{"simple float overflow", checkSimple, envconfig.MapSource{
+			"NAME": "foobar", "PORT": "80", "LONG": "1", "VERSION": "2", "DELTA": "1e40",
+		}, true},
+		{"simple invalid bytes", checkSimple, envconfig.MapSource{
+			"NAME": "foobar", "PORT": "80", "LONG": "1", "VERSION": "2",
+			"DELTA": "0.02", "DELTAV": "1", "DOIT": "true", "TIMEOUT": "1m",
+			"DATA": "foobar",
+		}, true},
+		{"simple invalid unmarshaler", checkSimple, envconfig.MapSource{
+			"NAME": "foobar", "PORT": "80", "LONG": "1", "VERSION": "2",
+			"DELTA": "0.02", "DELTAV": "1", "DOIT": "true", "TIMEOUT": "1m",
+			"DATA": "Rk9PQkFS", "MODE": "syslog",
+		}, true},
+
+		{"nested defaults", checkNested, envconfig.MapSource{
+			"LOG_PATH": "/var/log/foobar", "CASSANDRA_SSL_CERT": "cert", "CASSANDRA_SSLKEY": "key",
+		}, false},
+		{"nested", checkNested, envconfig.MapSource{
+			"LOG_PATH": "/var/log/foobar", "CASSANDRA_SSL_CERT": "cert", "cassandra_ssl_key": "key",
+			"MYSQL_MASTER_ADDRESS": "db", "MYSQL_MASTER_PORT": "3307", "myTimeout": "2m",
+			"OPTIONAL_A": "a", "OPTIONAL_B": "2", "INTERNAL": "foobar",
+		}, false},
+		{"nested missing key", checkNested, envconfig.MapSource{"LOG_PATH": "/var/log/foobar"}, true},
+		{"nested invalid default override", checkNested, envconfig.MapSource{
+			"LOG_PATH": "/var/log/foobar", "CASSANDRA_SSL_CERT": "cert", "CASSANDRA_SSLKEY": "key",
+			"MYSQL_MASTER_PORT": "foobar",
+		}, true},
+
+		{"pointers", checkPointers, envconfig.MapSource{
+			"NAME": "foobar", "PORT": "9000", "HOSTS": "localhost,free.fr",
+			"SHARDS": "{foobar,1},{barbaz,2}", "MASTER_NAME": "master", "MASTER_ADDR": "localhost:2727",
+			"TIMEOUT": "1m", "MODE": "file",
+		}, false},
+		{"pointers with optional", checkPointers, envconfig.MapSource{
+			"NAME": "foobar", "PORT": "9000", "HOSTS": "localhost",
+			"SHARDS": "{foobar,1}", "MASTER_NAME": "master", "MASTER_ADDR": "localhost:2727",
+			"TIMEOUT": "1m", "MODE": "file", "EXTRA": "extra",
+		}, false},
+		{"pointers invalid struct token", checkPointers, envconfig.MapSource{
+			"NAME": "foobar", "PORT": "9000", "HOSTS": "localhost", "SHARDS": "{foobar,barbaz}",
+		}, true},
+
+		{"slices", checkSlices, envconfig.MapSource{
+			"NAMES": "foobar,barbaz", "PORTS": "900,100", "SHARDS": "{foobar,1},{barbaz,2}",
+			"MODES": "file,stdout", "FLAGS": "true,false",
+		}, false},
+		{"slices single element", checkSlices, envconfig.MapSource{
+			"NAMES": "foobar", "PORTS": "900", "SHARDS": "{foobar,1}", "MODES": "file",
+		}, false},
+		{"slices wrong struct token", checkSlices, envconfig.MapSource{
+			"NAMES": "foobar", "PORTS": "900", "SHARDS": "foobar",
+		}, true},
+		{"slices escaped", checkSlices, envconfig.MapSource{
+			"NAMES": `"foo,bar",baz\,qux`, "PORTS": "900", "SHARDS": `{"a,b",1},{c\}d,2}`, "MODES": "file",
+		}, false},
+		{"slices unbalanced braces", checkSlices, envconfig.MapSource{
+			"NAMES": "{foo", "PORTS": "900", "SHARDS": "{a,1}",
+		}, true},
+		{"slices unbalanced struct braces", checkSlices, envconfig.MapSource{
+			"NAMES": "foo", "PORTS": "900", "SHARDS": "{a,1}}",
+		}, true},
+		{"slices wrong element", checkSlices, envconfig.MapSource{
+			"NAMES": "foobar", "PORTS": "900,foobar",
+		}, true},
+
+		{"defaults", checkDefaults, envconfig.MapSource{}, false},
+		{"defaults overridden", checkDefaults, envconfig.MapSource{
+			"NAMES": "a,b,c", "PORTS": "1", "SHARDS": "{a,1},{b,2},{c,3}", "customName": "bar",
+		}, false},
+
+		{"renamed", checkRenamed, envconfig.MapSource{"DATABASE_URL": "postgres://new", "PORT": "5432", "HOST": "localhost"}, false},
+		{"renamed explicit name first", checkRenamed, envconfig.MapSource{"DATABASE_URL": "postgres://new", "HOST": "localhost", "DATABASE_HOST": "db"}, false},
+		{"renamed deprecated keys", checkRenamed, envconfig.MapSource{"DB_URI": "postgres://old", "DB_PORT": "5432"}, false},
+		{"renamed same values", checkRenamed, envconfig.MapSource{"DATABASE_URL": "postgres://new", "DB_URL": "postgres://new"}, false},
+		{"renamed conflicting values", checkRenamed, envconfig.MapSource{"DATABASE_URL": "postgres://new", "DB_URL": "postgres://old"}, true},
+		{"renamed missing key", checkRenamed, envconfig.MapSource{}, true},
+
+		{"json", checkJSON, envconfig.MapSource{
+			"ROUTES": `{"eu":["a","b"]}`, "SHARDS": `[{"Name":"a","ID":1}]`, "PRIMARY": `{"Name":"b"}`, "LIMITS": `{"max":1}`,
+		}, false},
+		{"json defaults", checkJSON, envconfig.MapSource{"ROUTES": `{}`}, false},
+		{"json syntax error", checkJSON, envconfig.MapSource{"ROUTES": `{"eu":}`}, true},
+		{"json type error", checkJSON, envconfig.MapSource{"ROUTES": `{}`, "SHARDS": `{}`}, true},
+		{"sizes", checkSizes, envconfig.MapSource{
+			"BUFFER": "4KiB", "CACHE": "1.5GB", "UPLOAD": "10MB", "LIMITS": "512,1kB,2KiB", "FALLBACK": "-1",
+		}, false},
+		{"sizes defaults", checkSizes, envconfig.MapSource{"BUFFER": "512"}, false},
+		{"sizes overflow", checkSizes, envconfig.MapSource{"BUFFER": "1", "UPLOAD": "4GiB"}, true},
+		{"sizes unknown unit", checkSizes, envconfig.MapSource{"BUFFER": "1XB"}, true},
+		{"times", checkTimes, envconfig.MapSource{
+			"CUTOVER": "2024-03-01T12:30:00+01:00", "WINDOW": "2024-03-02 04:00", "CREATED": "1700000000",
+			"EXPIRES": "Mon, 02 Jan 2006 15:04:05 UTC", "HOLIDAYS": "2024-12-25,2025-01-01", "ZONE": "UTC",
+		}, false},
+		{"times defaults", checkTimes, envconfig.MapSource{"CUTOVER": "2024-03-01T12:30:00Z", "ZONE": "UTC"}, false},
+		{"times invalid layout", checkTimes, envconfig.MapSource{"CUTOVER": "2024-03-01"}, true},
+		{"times invalid unix", checkTimes, envconfig.MapSource{"CUTOVER": "2024-03-01T12:30:00Z", "CREATED": "now"}, true},
+		{"times invalid zone", checkTimes, envconfig.MapSource{"CUTOVER": "2024-03-01T12:30:00Z", "ZONE": "Mars/Olympus"}, true},
+		{"network", checkNetwork, envconfig.MapSource{
+			"ENDPOINT": "postgres://user@localhost:5432/app", "PROXY": "https://proxy.local:3128",
+			"MIRRORS": "https://a.example,https://b.example", "IP": "10.0.0.1", "DNS": "1.1.1.1,::1",
+			"ALLOWED": "10.1.2.3/8,fd00::/8", "MAC": "00:00:5e:00:53:01", "ADDR": "::1", "PREFIX": "172.16.0.0/12",
+			"PEERS": "10.0.0.2:7946,[fe80::1]:7946", "REDIS": "[::1]:http",
+		}, false},
+		{"network defaults", checkNetwork, envconfig.MapSource{"ENDPOINT": "/relative"}, false},
+		{"network scheme", checkNetwork, envconfig.MapSource{"ENDPOINT": "/", "PROXY": "socks5://proxy.local"}, true},
+		{"network invalid ip", checkNetwork, envconfig.MapSource{"ENDPOINT": "/", "DNS": "1.1.1.1,1.1.1"}, true},
+		{"network invalid cidr", checkNetwork, envconfig.MapSource{"ENDPOINT": "/", "ALLOWED": "10.0.0.0"}, true},
+		{"network invalid mac", checkNetwork, envconfig.MapSource{"ENDPOINT": "/", "MAC": "00:00"}, true},
+		{"network invalid addr port", checkNetwork, envconfig.MapSource{"ENDPOINT": "/", "PEERS": "localhost:80"}, true},
+		{"network invalid host port", checkNetwork, envconfig.MapSource{"ENDPOINT": "/", "REDIS": "localhost"}, true},
+		{"bools", checkBools, envconfig.MapSource{
+			"DEBUG": "on", "FEATURE_ENABLED": "Yes", "FEATURE_FLAGS": "y,n,enabled", "STRICT": "TRUE",
+		}, false},
+		{"bools strict", checkBools, envconfig.MapSource{"DEBUG": "on", "FEATURE_ENABLED": "Yes", "STRICT": "yes"}, true},
+		{"bools invalid", checkBools, envconfig.MapSource{"DEBUG": "maybe"}, true},
+		{"bytes", checkBytes, envconfig.MapSource{
+			"STD": "+/8=", "URL": "-_8", "HEX": "deadbeef", "PLAIN": "not encoded", "KEY": "ffffffff", "IV": "Rk9P",
+			"KEYS": "0102,0304",
+		}, false},
+		{"bytes defaults", checkBytes, envconfig.MapSource{"STD": "Rk9PQkFS"}, false},
+		{"bytes invalid", checkBytes, envconfig.MapSource{"STD": "Rk9PQkFS", "HEX": "xyz"}, true},
+		{"bytes array size", checkBytes, envconfig.MapSource{"STD": "Rk9PQkFS", "KEYS": "01,0203"}, true},
+		{"enums", checkEnums, envconfig.MapSource{
+			"LEVEL": "warn", "LEVELS": "error,debug", "FORMAT": "json", "COLOR": "green", "PRIORITY": "high",
+		}, false},
+		{"enums defaults", checkEnums, envconfig.MapSource{"LEVEL": "info"}, false},
+		{"enums invalid", checkEnums, envconfig.MapSource{"LEVEL": "trace"}, true},
+		{"enums invalid tag", checkEnums, envconfig.MapSource{"LEVEL": "info", "COLOR": "Red"}, true},
+		{"enums overflow", checkEnums, envconfig.MapSource{"LEVEL": "info", "PRIORITY": "low"}, true},
+		{"collections", checkCollections, envconfig.MapSource{
+			"MATRIX": "1;2|3", "WEIGHTS": "cpu:2;memory:1|disk:3", "ROUTES": "info:{a,1}|{b,2};error:{c,3}",
+			"LABELS": `url:"http://a;b"`, "GRID": "1h",
+		}, false},
+		{"collections defaults", checkCollections, envconfig.MapSource{"MATRIX": "1"}, false},
+		{"collections invalid element", checkCollections, envconfig.MapSource{"MATRIX": "1;2|3;x"}, true},
+		{"collections invalid map value", checkCollections, envconfig.MapSource{"MATRIX": "1", "WEIGHTS": "cpu:2|disk:-1"}, true},
+		{"collections invalid map key", checkCollections, envconfig.MapSource{"MATRIX": "1", "ROUTES": "trace:{a,1}"}, true},
+		{"collections invalid map entry", checkCollections, envconfig.MapSource{"MATRIX": "1", "LABELS": "team"}, true},
+		{"collections invalid quotes", checkCollections, envconfig.MapSource{"MATRIX": `1|"2`}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check(t, tc.src, tc.wantErr)
+		})
+	}
+}
+
+func checkSimple(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadSimple, src, wantErr)
+}
+func checkNested(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadNested, src, wantErr)
+}
+func checkPointers(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadPointers, src, wantErr)
+}
+func checkSlices(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadSlices, src, wantErr)
+}
+func checkDefaults(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadDefaults, src, wantErr)
+}
+func checkRenamed(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadRenamed, src, wantErr)
+}
+func checkJSON(t *testing.T, src envconfig.MapSource, wantErr bool) { check(t, LoadJSON, src, wantErr) }
+func checkSizes(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadSizes, src, wantErr)
+}
+func checkTimes(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadTimes, src, wantErr)
+}
+func checkNetwork(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadNetwork, src, wantErr)
+}
+func checkBools(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadBools, src, wantErr)
+}
+func checkBytes(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadBytes, src, wantErr)
+}
+func checkEnums(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadEnums, src, wantErr)
+}
+func checkCollections(t *testing.T, src envconfig.MapSource, wantErr bool) {
+	check(t, LoadCollections, src, wantErr)
+}
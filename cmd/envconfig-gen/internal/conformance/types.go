@@ -0,0 +1,200 @@
+// Package conformance contains the configuration types used to check that the code generated by envconfig-gen
+// behaves like envconfig.Init.
+package conformance
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vrischmann/envconfig"
+)
+
+//go:generate go run github.com/vrischmann/envconfig/cmd/envconfig-gen -type=Simple,Nested,Pointers,Slices,Defaults,Renamed,JSON,Sizes,Times,Network,Bools,Bytes,Enums,Collections -output=zz_envconfig.go
+
+type Simple struct {
+	Name    string
+	Port    int
+	Long    uint64
+	Version uint8
+	Delta   float32
+	DeltaV  float64
+	DoIt    bool
+	Timeout time.Duration
+	Data    []byte
+	Mode    LogMode
+}
+
+type Nested struct {
+	Log struct {
+		Path string
+	}
+	MySQL struct {
+		Master struct {
+			Address string `envconfig:"default=localhost"`
+			Port    int    `envconfig:"default=3306"`
+		}
+		Timeout time.Duration `envconfig:"default=1m,myTimeout"`
+	}
+	Cassandra struct {
+		SSLCert string
+		SslKey  string
+	}
+	Optional struct {
+		A string
+		B int
+	} `envconfig:"optional"`
+	Internal string `envconfig:"-"`
+}
+
+type Pointers struct {
+	Name   *string
+	Port   *int
+	Hosts  *[]string
+	Shards *[]*Shard
+	Master *struct {
+		Name *string
+		Addr *string
+	}
+	Timeout *time.Duration
+	Mode    *LogMode
+	Extra   *string `envconfig:"optional"`
+}
+
+type Slices struct {
+	Names  []string
+	Ports  []int
+	Shards []Shard
+	Modes  []LogMode
+	Flags  []bool `envconfig:"optional"`
+}
+
+type Defaults struct {
+	Names  []string `envconfig:"default=foobar;barbaz"`
+	Ports  []int    `envconfig:"default=900;100"`
+	Shards []Shard  `envconfig:"default={foobar;1};{barbaz;2}"`
+	Name   string   `envconfig:"default=foo,customName"`
+}
+
+type Renamed struct {
+	URL  string `envconfig:"name=DATABASE_URL,deprecated=DB_URL|DB_URI"`
+	Port int    `envconfig:"deprecated=DB_PORT,optional"`
+	Host string `envconfig:"names=DATABASE_HOST|auto,optional"`
+}
+
+type JSON struct {
+	Routes  map[string][]string `envconfig:"json"`
+	Shards  []Shard             `envconfig:"json,optional"`
+	Primary *Shard              `envconfig:"json,optional"`
+	Limits  struct {
+		Max int `json:"max"`
+	} `envconfig:"json,default={\"max\":10}"`
+}
+
+type Sizes struct {
+	Buffer   envconfig.ByteSize
+	Cache    int64    `envconfig:"unit=bytes,default=64MiB"`
+	Upload   uint32   `envconfig:"unit=bytes,optional"`
+	Limits   []uint64 `envconfig:"unit=bytes,optional"`
+	Fallback *int     `envconfig:"unit=bytes,optional"`
+}
+
+type Times struct {
+	Cutover  time.Time
+	Window   *time.Time  `envconfig:"layout=2006-01-02 15:04,optional"`
+	Created  time.Time   `envconfig:"layout=unix,optional"`
+	Expires  time.Time   `envconfig:"layout=RFC1123,optional"`
+	Holidays []time.Time `envconfig:"layout=DateOnly,optional"`
+	Zone     *time.Location
+	Start    time.Time `envconfig:"default=2024-01-01T02:00:00Z"`
+}
+
+type Network struct {
+	Endpoint url.URL
+	Proxy    *url.URL           `envconfig:"schemes=http|https,optional"`
+	Mirrors  []*url.URL         `envconfig:"optional"`
+	IP       net.IP             `envconfig:"default=127.0.0.1"`
+	DNS      []net.IP           `envconfig:"optional"`
+	Allowed  []net.IPNet        `envconfig:"optional"`
+	MAC      net.HardwareAddr   `envconfig:"optional"`
+	Addr     netip.Addr         `envconfig:"optional"`
+	Prefix   *netip.Prefix      `envconfig:"optional"`
+	Peers    []netip.AddrPort   `envconfig:"optional"`
+	Redis    envconfig.HostPort `envconfig:"default=localhost:6379"`
+}
+
+type Bools struct {
+	Debug   bool `envconfig:"extendedbool"`
+	Feature struct {
+		Enabled bool
+		Flags   []bool `envconfig:"optional"`
+	} `envconfig:"extendedbool"`
+	Strict bool `envconfig:"default=false"`
+}
+
+type Bytes struct {
+	Std   []byte    `envconfig:"encoding=base64"`
+	URL   []byte    `envconfig:"encoding=base64url,optional"`
+	Hex   []byte    `envconfig:"encoding=hex,optional"`
+	Plain []byte    `envconfig:"encoding=raw,optional"`
+	Key   [4]byte   `envconfig:"encoding=hex,default=00010203"`
+	IV    *[3]byte  `envconfig:"optional"`
+	Keys  [][2]byte `envconfig:"encoding=hex,optional"`
+}
+
+type Enums struct {
+	Level    Level
+	Levels   []Level `envconfig:"optional"`
+	Format   *Format `envconfig:"default=text"`
+	Color    string  `envconfig:"enum=red|green|blue,optional"`
+	Priority uint8   `envconfig:"enum=low:-1|normal:1|high:200,default=normal"`
+}
+
+type Collections struct {
+	Matrix  [][]int             `envconfig:"sep=|;"`
+	Weights []map[string]uint16 `envconfig:"sep=|;,optional"`
+	Routes  map[Level][]*Shard  `envconfig:"sep=;|,optional"`
+	Labels  map[string]string   `envconfig:"sep=;,default=team:core;tier:1"`
+	Grid    [][]time.Duration   `envconfig:"sep=/;,default=1s;2s/1m"`
+}
+
+// Indexed is not supported by envconfig-gen.
+type Indexed struct {
+	Hosts []string `envconfig:"indexed"`
+}
+
+type Shard struct {
+	Name string
+	ID   int
+}
+
+type LogMode uint
+
+const (
+	LogFile LogMode = iota + 1
+	LogStdout
+)
+
+type Level int
+
+func (Level) EnumValues() []string { return []string{"debug", "info", "warn:4", "error:8"} }
+
+type Format string
+
+func (*Format) EnumValues() []string { return []string{"text", "json"} }
+
+func (m *LogMode) Unmarshal(s string) error {
+	switch strings.ToLower(s) {
+	case "file":
+		*m = LogFile
+	case "stdout":
+		*m = LogStdout
+	default:
+		return fmt.Errorf("unable to unmarshal %s", s)
+	}
+
+	return nil
+}
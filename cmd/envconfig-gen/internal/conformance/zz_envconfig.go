@@ -0,0 +1,2211 @@
+// Code generated by envconfig-gen. DO NOT EDIT.
+
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"time"
+
+	"github.com/vrischmann/envconfig/envconfigrt"
+)
+
+// LoadSimple creates a Simple and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadSimple(lookup func(string) (string, bool)) (Simple, error) {
+	var conf Simple
+
+	err := func() error {
+		// Name
+		{
+			keys := []string{"NAME", "name"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.Name = string(str)
+			}
+		}
+
+		// Port
+		{
+			keys := []string{"PORT", "port"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseInt(str, 0)
+					if err != nil {
+						return err
+					}
+					conf.Port = int(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Long
+		{
+			keys := []string{"LONG", "long"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseUint(str, 64)
+					if err != nil {
+						return err
+					}
+					conf.Long = uint64(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Version
+		{
+			keys := []string{"VERSION", "version"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseUint(str, 8)
+					if err != nil {
+						return err
+					}
+					conf.Version = uint8(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Delta
+		{
+			keys := []string{"DELTA", "delta"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseFloat(str, 32)
+					if err != nil {
+						return err
+					}
+					conf.Delta = float32(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// DeltaV
+		{
+			keys := []string{"DELTA_V", "delta_v", "DELTAV", "deltav"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseFloat(str, 64)
+					if err != nil {
+						return err
+					}
+					conf.DeltaV = float64(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// DoIt
+		{
+			keys := []string{"DO_IT", "do_it", "DOIT", "doit"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseBool(str)
+					if err != nil {
+						return err
+					}
+					conf.DoIt = bool(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Timeout
+		{
+			keys := []string{"TIMEOUT", "timeout"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseDuration(str)
+					if err != nil {
+						return err
+					}
+					conf.Timeout = time.Duration(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Data
+		{
+			keys := []string{"DATA", "data"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				v, err := envconfigrt.ParseBytes(str, "")
+				if err != nil {
+					return envconfigrt.WrapBytesParseError(str, keys, err)
+				}
+				conf.Data = v
+			}
+		}
+
+		// Mode
+		{
+			keys := []string{"MODE", "mode"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					return conf.Mode.Unmarshal(str)
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Simple{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadNested creates a Nested and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadNested(lookup func(string) (string, bool)) (Nested, error) {
+	var conf Nested
+
+	err := func() error {
+		// Log.Path
+		{
+			keys := []string{"LOG_PATH", "log_path"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.Log.Path = string(str)
+			}
+		}
+
+		// MySQL.Master.Address
+		{
+			keys := []string{"MY_SQL_MASTER_ADDRESS", "my_sql_master_address", "MYSQL_MASTER_ADDRESS", "mysql_master_address"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "localhost", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.MySQL.Master.Address = string(str)
+			}
+		}
+
+		// MySQL.Master.Port
+		{
+			keys := []string{"MY_SQL_MASTER_PORT", "my_sql_master_port", "MYSQL_MASTER_PORT", "mysql_master_port"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "3306", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseInt(str, 0)
+					if err != nil {
+						return err
+					}
+					conf.MySQL.Master.Port = int(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// MySQL.Timeout
+		{
+			keys := []string{"myTimeout"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "1m", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseDuration(str)
+					if err != nil {
+						return err
+					}
+					conf.MySQL.Timeout = time.Duration(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Cassandra.SSLCert
+		{
+			keys := []string{"CASSANDRA_SSL_CERT", "cassandra_ssl_cert", "CASSANDRA_SSLCERT", "cassandra_sslcert"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.Cassandra.SSLCert = string(str)
+			}
+		}
+
+		// Cassandra.SslKey
+		{
+			keys := []string{"CASSANDRA_SSL_KEY", "cassandra_ssl_key", "CASSANDRA_SSLKEY", "cassandra_sslkey"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.Cassandra.SslKey = string(str)
+			}
+		}
+
+		// Optional.A
+		{
+			keys := []string{"OPTIONAL_A", "optional_a"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.Optional.A = string(str)
+			}
+		}
+
+		// Optional.B
+		{
+			keys := []string{"OPTIONAL_B", "optional_b"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseInt(str, 0)
+					if err != nil {
+						return err
+					}
+					conf.Optional.B = int(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Nested{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadPointers creates a Pointers and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadPointers(lookup func(string) (string, bool)) (Pointers, error) {
+	var conf Pointers
+
+	err := func() error {
+		conf.Name = new(string)
+		// Name
+		{
+			keys := []string{"NAME", "name"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				(*conf.Name) = string(str)
+			}
+		}
+
+		conf.Port = new(int)
+		// Port
+		{
+			keys := []string{"PORT", "port"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseInt(str, 0)
+					if err != nil {
+						return err
+					}
+					(*conf.Port) = int(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		conf.Hosts = new([]string)
+		// Hosts
+		{
+			keys := []string{"HOSTS", "hosts"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens1, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s2 := make([]string, 0, len(tokens1))
+				for _, tok := range tokens1 {
+					var el3 string
+					el3 = string(tok)
+					s2 = append(s2, el3)
+				}
+				(*conf.Hosts) = s2
+			}
+		}
+
+		conf.Shards = new([]*Shard)
+		// Shards
+		{
+			keys := []string{"SHARDS", "shards"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens4, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s5 := make([]*Shard, 0, len(tokens4))
+				for _, tok := range tokens4 {
+					var el6 *Shard
+					el6 = new(Shard)
+					if err := func() error {
+						tokens7, err := envconfigrt.SplitStruct(tok, usingDefault, 2)
+						if err != nil {
+							return err
+						}
+						(*el6).Name = string(tokens7[0])
+						if err := func() error {
+							v, err := envconfigrt.ParseInt(tokens7[1], 0)
+							if err != nil {
+								return err
+							}
+							(*el6).ID = int(v)
+							return nil
+						}(); err != nil {
+							return envconfigrt.WrapParseError(tokens7[1], keys, err)
+						}
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s5 = append(s5, el6)
+				}
+				(*conf.Shards) = s5
+			}
+		}
+
+		conf.Master = new(struct {
+			Name *string
+			Addr *string
+		})
+		(*conf.Master).Name = new(string)
+		// Master.Name
+		{
+			keys := []string{"MASTER_NAME", "master_name"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				(*(*conf.Master).Name) = string(str)
+			}
+		}
+
+		(*conf.Master).Addr = new(string)
+		// Master.Addr
+		{
+			keys := []string{"MASTER_ADDR", "master_addr"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				(*(*conf.Master).Addr) = string(str)
+			}
+		}
+
+		conf.Timeout = new(time.Duration)
+		// Timeout
+		{
+			keys := []string{"TIMEOUT", "timeout"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseDuration(str)
+					if err != nil {
+						return err
+					}
+					(*conf.Timeout) = time.Duration(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Mode
+		{
+			keys := []string{"MODE", "mode"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					if conf.Mode == nil {
+						conf.Mode = new(LogMode)
+					}
+					return conf.Mode.Unmarshal(str)
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		conf.Extra = new(string)
+		// Extra
+		{
+			keys := []string{"EXTRA", "extra"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				(*conf.Extra) = string(str)
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Pointers{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadSlices creates a Slices and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadSlices(lookup func(string) (string, bool)) (Slices, error) {
+	var conf Slices
+
+	err := func() error {
+		// Names
+		{
+			keys := []string{"NAMES", "names"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens8, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s9 := make([]string, 0, len(tokens8))
+				for _, tok := range tokens8 {
+					var el10 string
+					el10 = string(tok)
+					s9 = append(s9, el10)
+				}
+				conf.Names = s9
+			}
+		}
+
+		// Ports
+		{
+			keys := []string{"PORTS", "ports"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens11, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s12 := make([]int, 0, len(tokens11))
+				for _, tok := range tokens11 {
+					var el13 int
+					if err := func() error {
+						v, err := envconfigrt.ParseInt(tok, 0)
+						if err != nil {
+							return err
+						}
+						el13 = int(v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s12 = append(s12, el13)
+				}
+				conf.Ports = s12
+			}
+		}
+
+		// Shards
+		{
+			keys := []string{"SHARDS", "shards"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens14, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s15 := make([]Shard, 0, len(tokens14))
+				for _, tok := range tokens14 {
+					var el16 Shard
+					if err := func() error {
+						tokens17, err := envconfigrt.SplitStruct(tok, usingDefault, 2)
+						if err != nil {
+							return err
+						}
+						el16.Name = string(tokens17[0])
+						if err := func() error {
+							v, err := envconfigrt.ParseInt(tokens17[1], 0)
+							if err != nil {
+								return err
+							}
+							el16.ID = int(v)
+							return nil
+						}(); err != nil {
+							return envconfigrt.WrapParseError(tokens17[1], keys, err)
+						}
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s15 = append(s15, el16)
+				}
+				conf.Shards = s15
+			}
+		}
+
+		// Modes
+		{
+			keys := []string{"MODES", "modes"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens18, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s19 := make([]LogMode, 0, len(tokens18))
+				for _, tok := range tokens18 {
+					var el20 LogMode
+					if err := func() error {
+						return el20.Unmarshal(tok)
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s19 = append(s19, el20)
+				}
+				conf.Modes = s19
+			}
+		}
+
+		// Flags
+		{
+			keys := []string{"FLAGS", "flags"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens21, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s22 := make([]bool, 0, len(tokens21))
+				for _, tok := range tokens21 {
+					var el23 bool
+					if err := func() error {
+						v, err := envconfigrt.ParseBool(tok)
+						if err != nil {
+							return err
+						}
+						el23 = bool(v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s22 = append(s22, el23)
+				}
+				conf.Flags = s22
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Slices{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadDefaults creates a Defaults and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadDefaults(lookup func(string) (string, bool)) (Defaults, error) {
+	var conf Defaults
+
+	err := func() error {
+		// Names
+		{
+			keys := []string{"NAMES", "names"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "foobar;barbaz", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens24, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s25 := make([]string, 0, len(tokens24))
+				for _, tok := range tokens24 {
+					var el26 string
+					el26 = string(tok)
+					s25 = append(s25, el26)
+				}
+				conf.Names = s25
+			}
+		}
+
+		// Ports
+		{
+			keys := []string{"PORTS", "ports"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "900;100", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens27, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s28 := make([]int, 0, len(tokens27))
+				for _, tok := range tokens27 {
+					var el29 int
+					if err := func() error {
+						v, err := envconfigrt.ParseInt(tok, 0)
+						if err != nil {
+							return err
+						}
+						el29 = int(v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s28 = append(s28, el29)
+				}
+				conf.Ports = s28
+			}
+		}
+
+		// Shards
+		{
+			keys := []string{"SHARDS", "shards"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "{foobar;1};{barbaz;2}", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens30, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s31 := make([]Shard, 0, len(tokens30))
+				for _, tok := range tokens30 {
+					var el32 Shard
+					if err := func() error {
+						tokens33, err := envconfigrt.SplitStruct(tok, usingDefault, 2)
+						if err != nil {
+							return err
+						}
+						el32.Name = string(tokens33[0])
+						if err := func() error {
+							v, err := envconfigrt.ParseInt(tokens33[1], 0)
+							if err != nil {
+								return err
+							}
+							el32.ID = int(v)
+							return nil
+						}(); err != nil {
+							return envconfigrt.WrapParseError(tokens33[1], keys, err)
+						}
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s31 = append(s31, el32)
+				}
+				conf.Shards = s31
+			}
+		}
+
+		// Name
+		{
+			keys := []string{"customName"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "foo", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.Name = string(str)
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Defaults{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadRenamed creates a Renamed and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadRenamed(lookup func(string) (string, bool)) (Renamed, error) {
+	var conf Renamed
+
+	err := func() error {
+		// URL
+		{
+			keys := []string{"DATABASE_URL"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false, "DB_URL", "DB_URI")
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.URL = string(str)
+			}
+		}
+
+		// Port
+		{
+			keys := []string{"PORT", "port"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true, "DB_PORT")
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseInt(str, 0)
+					if err != nil {
+						return err
+					}
+					conf.Port = int(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Host
+		{
+			keys := []string{"DATABASE_HOST", "HOST", "host"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				conf.Host = string(str)
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Renamed{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadJSON creates a JSON and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadJSON(lookup func(string) (string, bool)) (JSON, error) {
+	var conf JSON
+
+	err := func() error {
+		// Routes
+		{
+			keys := []string{"ROUTES", "routes"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				var v map[string][]string
+				if err := json.Unmarshal([]byte(str), &v); err != nil {
+					return envconfigrt.WrapJSONError("Routes", keys, err)
+				}
+				conf.Routes = v
+			}
+		}
+
+		// Shards
+		{
+			keys := []string{"SHARDS", "shards"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				var v []Shard
+				if err := json.Unmarshal([]byte(str), &v); err != nil {
+					return envconfigrt.WrapJSONError("Shards", keys, err)
+				}
+				conf.Shards = v
+			}
+		}
+
+		// Primary
+		{
+			keys := []string{"PRIMARY", "primary"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				var v *Shard
+				if err := json.Unmarshal([]byte(str), &v); err != nil {
+					return envconfigrt.WrapJSONError("Primary", keys, err)
+				}
+				conf.Primary = v
+			}
+		}
+
+		// Limits
+		{
+			keys := []string{"LIMITS", "limits"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "{\"max\":10}", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				var v struct {
+					Max int "json:\"max\""
+				}
+				if err := json.Unmarshal([]byte(str), &v); err != nil {
+					return envconfigrt.WrapJSONError("Limits", keys, err)
+				}
+				conf.Limits = v
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return JSON{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadSizes creates a Sizes and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadSizes(lookup func(string) (string, bool)) (Sizes, error) {
+	var conf Sizes
+
+	err := func() error {
+		// Buffer
+		{
+			keys := []string{"BUFFER", "buffer"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					return conf.Buffer.Unmarshal(str)
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Cache
+		{
+			keys := []string{"CACHE", "cache"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "64MiB", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseIntBytes(str, 64)
+					if err != nil {
+						return err
+					}
+					conf.Cache = int64(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Upload
+		{
+			keys := []string{"UPLOAD", "upload"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseUintBytes(str, 32)
+					if err != nil {
+						return err
+					}
+					conf.Upload = uint32(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Limits
+		{
+			keys := []string{"LIMITS", "limits"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens34, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s35 := make([]uint64, 0, len(tokens34))
+				for _, tok := range tokens34 {
+					var el36 uint64
+					if err := func() error {
+						v, err := envconfigrt.ParseUintBytes(tok, 64)
+						if err != nil {
+							return err
+						}
+						el36 = uint64(v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s35 = append(s35, el36)
+				}
+				conf.Limits = s35
+			}
+		}
+
+		conf.Fallback = new(int)
+		// Fallback
+		{
+			keys := []string{"FALLBACK", "fallback"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseIntBytes(str, 0)
+					if err != nil {
+						return err
+					}
+					(*conf.Fallback) = int(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Sizes{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadTimes creates a Times and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadTimes(lookup func(string) (string, bool)) (Times, error) {
+	var conf Times
+
+	err := func() error {
+		// Cutover
+		{
+			keys := []string{"CUTOVER", "cutover"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseTime(str, "")
+					if err != nil {
+						return err
+					}
+					conf.Cutover = time.Time(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		conf.Window = new(time.Time)
+		// Window
+		{
+			keys := []string{"WINDOW", "window"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseTime(str, "2006-01-02 15:04")
+					if err != nil {
+						return err
+					}
+					(*conf.Window) = time.Time(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Created
+		{
+			keys := []string{"CREATED", "created"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseTime(str, "unix")
+					if err != nil {
+						return err
+					}
+					conf.Created = time.Time(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Expires
+		{
+			keys := []string{"EXPIRES", "expires"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseTime(str, "RFC1123")
+					if err != nil {
+						return err
+					}
+					conf.Expires = time.Time(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Holidays
+		{
+			keys := []string{"HOLIDAYS", "holidays"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens37, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s38 := make([]time.Time, 0, len(tokens37))
+				for _, tok := range tokens37 {
+					var el39 time.Time
+					if err := func() error {
+						v, err := envconfigrt.ParseTime(tok, "DateOnly")
+						if err != nil {
+							return err
+						}
+						el39 = time.Time(v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s38 = append(s38, el39)
+				}
+				conf.Holidays = s38
+			}
+		}
+
+		// Zone
+		{
+			keys := []string{"ZONE", "zone"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseLocation(str)
+					if err != nil {
+						return err
+					}
+					conf.Zone = v
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Start
+		{
+			keys := []string{"START", "start"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "2024-01-01T02:00:00Z", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseTime(str, "")
+					if err != nil {
+						return err
+					}
+					conf.Start = time.Time(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Times{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadNetwork creates a Network and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadNetwork(lookup func(string) (string, bool)) (Network, error) {
+	var conf Network
+
+	err := func() error {
+		// Endpoint
+		{
+			keys := []string{"ENDPOINT", "endpoint"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseURL(str)
+					if err != nil {
+						return err
+					}
+					conf.Endpoint = *v
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		conf.Proxy = new(url.URL)
+		// Proxy
+		{
+			keys := []string{"PROXY", "proxy"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseURL(str, "http", "https")
+					if err != nil {
+						return err
+					}
+					(*conf.Proxy) = *v
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Mirrors
+		{
+			keys := []string{"MIRRORS", "mirrors"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens40, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s41 := make([]*url.URL, 0, len(tokens40))
+				for _, tok := range tokens40 {
+					var el42 *url.URL
+					el42 = new(url.URL)
+					if err := func() error {
+						v, err := envconfigrt.ParseURL(tok)
+						if err != nil {
+							return err
+						}
+						(*el42) = *v
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s41 = append(s41, el42)
+				}
+				conf.Mirrors = s41
+			}
+		}
+
+		// IP
+		{
+			keys := []string{"IP", "ip"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "127.0.0.1", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseIP(str)
+					if err != nil {
+						return err
+					}
+					conf.IP = net.IP(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// DNS
+		{
+			keys := []string{"DNS", "dns"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens43, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s44 := make([]net.IP, 0, len(tokens43))
+				for _, tok := range tokens43 {
+					var el45 net.IP
+					if err := func() error {
+						v, err := envconfigrt.ParseIP(tok)
+						if err != nil {
+							return err
+						}
+						el45 = net.IP(v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s44 = append(s44, el45)
+				}
+				conf.DNS = s44
+			}
+		}
+
+		// Allowed
+		{
+			keys := []string{"ALLOWED", "allowed"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens46, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s47 := make([]net.IPNet, 0, len(tokens46))
+				for _, tok := range tokens46 {
+					var el48 net.IPNet
+					if err := func() error {
+						v, err := envconfigrt.ParseIPNet(tok)
+						if err != nil {
+							return err
+						}
+						el48 = *v
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s47 = append(s47, el48)
+				}
+				conf.Allowed = s47
+			}
+		}
+
+		// MAC
+		{
+			keys := []string{"MAC", "mac"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := net.ParseMAC(str)
+					if err != nil {
+						return err
+					}
+					conf.MAC = net.HardwareAddr(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Addr
+		{
+			keys := []string{"ADDR", "addr"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := netip.ParseAddr(str)
+					if err != nil {
+						return err
+					}
+					conf.Addr = netip.Addr(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		conf.Prefix = new(netip.Prefix)
+		// Prefix
+		{
+			keys := []string{"PREFIX", "prefix"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := netip.ParsePrefix(str)
+					if err != nil {
+						return err
+					}
+					(*conf.Prefix) = netip.Prefix(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Peers
+		{
+			keys := []string{"PEERS", "peers"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens49, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s50 := make([]netip.AddrPort, 0, len(tokens49))
+				for _, tok := range tokens49 {
+					var el51 netip.AddrPort
+					if err := func() error {
+						v, err := netip.ParseAddrPort(tok)
+						if err != nil {
+							return err
+						}
+						el51 = netip.AddrPort(v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s50 = append(s50, el51)
+				}
+				conf.Peers = s50
+			}
+		}
+
+		// Redis
+		{
+			keys := []string{"REDIS", "redis"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "localhost:6379", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					return conf.Redis.Unmarshal(str)
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Network{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadBools creates a Bools and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadBools(lookup func(string) (string, bool)) (Bools, error) {
+	var conf Bools
+
+	err := func() error {
+		// Debug
+		{
+			keys := []string{"DEBUG", "debug"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseExtendedBool(str)
+					if err != nil {
+						return err
+					}
+					conf.Debug = bool(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Feature.Enabled
+		{
+			keys := []string{"FEATURE_ENABLED", "feature_enabled"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseExtendedBool(str)
+					if err != nil {
+						return err
+					}
+					conf.Feature.Enabled = bool(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Feature.Flags
+		{
+			keys := []string{"FEATURE_FLAGS", "feature_flags"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens52, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s53 := make([]bool, 0, len(tokens52))
+				for _, tok := range tokens52 {
+					var el54 bool
+					if err := func() error {
+						v, err := envconfigrt.ParseExtendedBool(tok)
+						if err != nil {
+							return err
+						}
+						el54 = bool(v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s53 = append(s53, el54)
+				}
+				conf.Feature.Flags = s53
+			}
+		}
+
+		// Strict
+		{
+			keys := []string{"STRICT", "strict"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "false", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseBool(str)
+					if err != nil {
+						return err
+					}
+					conf.Strict = bool(v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Bools{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadBytes creates a Bytes and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadBytes(lookup func(string) (string, bool)) (Bytes, error) {
+	var conf Bytes
+
+	err := func() error {
+		// Std
+		{
+			keys := []string{"STD", "std"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				v, err := envconfigrt.ParseBytes(str, "base64")
+				if err != nil {
+					return envconfigrt.WrapBytesParseError(str, keys, err)
+				}
+				conf.Std = v
+			}
+		}
+
+		// URL
+		{
+			keys := []string{"URL", "url"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				v, err := envconfigrt.ParseBytes(str, "base64url")
+				if err != nil {
+					return envconfigrt.WrapBytesParseError(str, keys, err)
+				}
+				conf.URL = v
+			}
+		}
+
+		// Hex
+		{
+			keys := []string{"HEX", "hex"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				v, err := envconfigrt.ParseBytes(str, "hex")
+				if err != nil {
+					return envconfigrt.WrapBytesParseError(str, keys, err)
+				}
+				conf.Hex = v
+			}
+		}
+
+		// Plain
+		{
+			keys := []string{"PLAIN", "plain"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				v, err := envconfigrt.ParseBytes(str, "raw")
+				if err != nil {
+					return envconfigrt.WrapBytesParseError(str, keys, err)
+				}
+				conf.Plain = v
+			}
+		}
+
+		// Key
+		{
+			keys := []string{"KEY", "key"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "00010203", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseByteArray(str, "hex", 4)
+					if err != nil {
+						return err
+					}
+					copy(conf.Key[:], v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		conf.IV = new([3]byte)
+		// IV
+		{
+			keys := []string{"IV", "iv"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseByteArray(str, "", 3)
+					if err != nil {
+						return err
+					}
+					copy((*conf.IV)[:], v)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Keys
+		{
+			keys := []string{"KEYS", "keys"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens55, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s56 := make([][2]byte, 0, len(tokens55))
+				for _, tok := range tokens55 {
+					var el57 [2]byte
+					if err := func() error {
+						v, err := envconfigrt.ParseByteArray(tok, "hex", 2)
+						if err != nil {
+							return err
+						}
+						copy(el57[:], v)
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s56 = append(s56, el57)
+				}
+				conf.Keys = s56
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Bytes{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadEnums creates a Enums and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadEnums(lookup func(string) (string, bool)) (Enums, error) {
+	var conf Enums
+
+	err := func() error {
+		// Level
+		{
+			keys := []string{"LEVEL", "level"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseEnum(str, new(Level).EnumValues())
+					if err != nil {
+						return err
+					}
+					conf.Level = Level(v)
+					if int64(conf.Level) != v {
+						return fmt.Errorf("value %d of %q overflows conformance.Level", v, str)
+					}
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Levels
+		{
+			keys := []string{"LEVELS", "levels"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens58, err := envconfigrt.SplitSlice(str, usingDefault)
+				if err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+				s59 := make([]Level, 0, len(tokens58))
+				for _, tok := range tokens58 {
+					var el60 Level
+					if err := func() error {
+						v, err := envconfigrt.ParseEnum(tok, new(Level).EnumValues())
+						if err != nil {
+							return err
+						}
+						el60 = Level(v)
+						if int64(el60) != v {
+							return fmt.Errorf("value %d of %q overflows conformance.Level", v, tok)
+						}
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapParseError(tok, keys, err)
+					}
+					s59 = append(s59, el60)
+				}
+				conf.Levels = s59
+			}
+		}
+
+		conf.Format = new(Format)
+		// Format
+		{
+			keys := []string{"FORMAT", "format"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "text", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					if _, err := envconfigrt.ParseEnum(str, new(Format).EnumValues()); err != nil {
+						return err
+					}
+					(*conf.Format) = Format(str)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Color
+		{
+			keys := []string{"COLOR", "color"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					if _, err := envconfigrt.ParseEnum(str, []string{"red", "green", "blue"}); err != nil {
+						return err
+					}
+					conf.Color = string(str)
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		// Priority
+		{
+			keys := []string{"PRIORITY", "priority"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "normal", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				if err := func() error {
+					v, err := envconfigrt.ParseEnum(str, []string{"low:-1", "normal:1", "high:200"})
+					if err != nil {
+						return err
+					}
+					conf.Priority = uint8(v)
+					if v < 0 || int64(conf.Priority) != v {
+						return fmt.Errorf("value %d of %q overflows uint8", v, str)
+					}
+					return nil
+				}(); err != nil {
+					return envconfigrt.WrapParseError(str, keys, err)
+				}
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Enums{}, err
+	}
+
+	return conf, nil
+}
+
+// LoadCollections creates a Collections and populates it with the values returned by lookup,
+// following the same rules as envconfig.Init.
+func LoadCollections(lookup func(string) (string, bool)) (Collections, error) {
+	var conf Collections
+
+	err := func() error {
+		// Matrix
+		{
+			keys := []string{"MATRIX", "matrix"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens61, err := envconfigrt.SplitCollection(str, "|;", 0)
+				if err != nil {
+					return envconfigrt.WrapElementParseError(str, "Matrix", keys, err)
+				}
+				s63 := make([][]int, 0, len(tokens61))
+				for i64, tok62 := range tokens61 {
+					var el65 []int
+					tokens66, err := envconfigrt.SplitCollection(tok62, "|;", 1)
+					if err != nil {
+						return envconfigrt.WrapElementParseError(tok62, fmt.Sprintf("Matrix[%d]", i64), keys, err)
+					}
+					s68 := make([]int, 0, len(tokens66))
+					for i69, tok67 := range tokens66 {
+						var el70 int
+						if err := func() error {
+							v, err := envconfigrt.ParseInt(tok67, 0)
+							if err != nil {
+								return err
+							}
+							el70 = int(v)
+							return nil
+						}(); err != nil {
+							return envconfigrt.WrapElementParseError(tok67, fmt.Sprintf("Matrix[%d][%d]", i64, i69), keys, err)
+						}
+						s68 = append(s68, el70)
+					}
+					el65 = s68
+					s63 = append(s63, el65)
+				}
+				conf.Matrix = s63
+			}
+		}
+
+		// Weights
+		{
+			keys := []string{"WEIGHTS", "weights"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens71, err := envconfigrt.SplitCollection(str, "|;", 0)
+				if err != nil {
+					return envconfigrt.WrapElementParseError(str, "Weights", keys, err)
+				}
+				s73 := make([]map[string]uint16, 0, len(tokens71))
+				for i74, tok72 := range tokens71 {
+					var el75 map[string]uint16
+					tokens76, err := envconfigrt.SplitMap(tok72, "|;", 1)
+					if err != nil {
+						return envconfigrt.WrapElementParseError(tok72, fmt.Sprintf("Weights[%d]", i74), keys, err)
+					}
+					m78 := make(map[string]uint16, len(tokens76))
+					for _, tok77 := range tokens76 {
+						k79, v80, err := envconfigrt.SplitMapEntry(tok77)
+						if err != nil {
+							return envconfigrt.WrapElementParseError(tok77, fmt.Sprintf("Weights[%d]", i74), keys, err)
+						}
+						var key81 string
+						key81 = string(k79)
+						var el82 uint16
+						if err := func() error {
+							v, err := envconfigrt.ParseUint(v80, 16)
+							if err != nil {
+								return err
+							}
+							el82 = uint16(v)
+							return nil
+						}(); err != nil {
+							return envconfigrt.WrapElementParseError(v80, fmt.Sprintf("Weights[%d][%s]", i74, k79), keys, err)
+						}
+						m78[key81] = el82
+					}
+					el75 = m78
+					s73 = append(s73, el75)
+				}
+				conf.Weights = s73
+			}
+		}
+
+		// Routes
+		{
+			keys := []string{"ROUTES", "routes"}
+			str, usingDefault, err := envconfigrt.ReadValue(lookup, keys, "", true)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens83, err := envconfigrt.SplitMap(str, ";|", 0)
+				if err != nil {
+					return envconfigrt.WrapElementParseError(str, "Routes", keys, err)
+				}
+				m85 := make(map[Level][]*Shard, len(tokens83))
+				for _, tok84 := range tokens83 {
+					k86, v87, err := envconfigrt.SplitMapEntry(tok84)
+					if err != nil {
+						return envconfigrt.WrapElementParseError(tok84, "Routes", keys, err)
+					}
+					var key88 Level
+					if err := func() error {
+						v, err := envconfigrt.ParseEnum(k86, new(Level).EnumValues())
+						if err != nil {
+							return err
+						}
+						key88 = Level(v)
+						if int64(key88) != v {
+							return fmt.Errorf("value %d of %q overflows conformance.Level", v, k86)
+						}
+						return nil
+					}(); err != nil {
+						return envconfigrt.WrapElementParseError(k86, fmt.Sprintf("Routes[%s]", k86), keys, err)
+					}
+					var el89 []*Shard
+					tokens90, err := envconfigrt.SplitCollection(v87, ";|", 1)
+					if err != nil {
+						return envconfigrt.WrapElementParseError(v87, fmt.Sprintf("Routes[%s]", k86), keys, err)
+					}
+					s92 := make([]*Shard, 0, len(tokens90))
+					for i93, tok91 := range tokens90 {
+						var el94 *Shard
+						el94 = new(Shard)
+						if err := func() error {
+							tokens95, err := envconfigrt.SplitStruct(tok91, usingDefault, 2)
+							if err != nil {
+								return err
+							}
+							(*el94).Name = string(tokens95[0])
+							if err := func() error {
+								v, err := envconfigrt.ParseInt(tokens95[1], 0)
+								if err != nil {
+									return err
+								}
+								(*el94).ID = int(v)
+								return nil
+							}(); err != nil {
+								return envconfigrt.WrapElementParseError(tokens95[1], fmt.Sprintf("Routes[%s][%d]", k86, i93), keys, err)
+							}
+							return nil
+						}(); err != nil {
+							return envconfigrt.WrapElementParseError(tok91, fmt.Sprintf("Routes[%s][%d]", k86, i93), keys, err)
+						}
+						s92 = append(s92, el94)
+					}
+					el89 = s92
+					m85[key88] = el89
+				}
+				conf.Routes = m85
+			}
+		}
+
+		// Labels
+		{
+			keys := []string{"LABELS", "labels"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "team:core;tier:1", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens96, err := envconfigrt.SplitMap(str, ";", 0)
+				if err != nil {
+					return envconfigrt.WrapElementParseError(str, "Labels", keys, err)
+				}
+				m98 := make(map[string]string, len(tokens96))
+				for _, tok97 := range tokens96 {
+					k99, v100, err := envconfigrt.SplitMapEntry(tok97)
+					if err != nil {
+						return envconfigrt.WrapElementParseError(tok97, "Labels", keys, err)
+					}
+					var key101 string
+					key101 = string(k99)
+					var el102 string
+					el102 = string(v100)
+					m98[key101] = el102
+				}
+				conf.Labels = m98
+			}
+		}
+
+		// Grid
+		{
+			keys := []string{"GRID", "grid"}
+			str, _, err := envconfigrt.ReadValue(lookup, keys, "1s;2s/1m", false)
+			if err != nil {
+				return err
+			}
+			if str != "" {
+				tokens103, err := envconfigrt.SplitCollection(str, "/;", 0)
+				if err != nil {
+					return envconfigrt.WrapElementParseError(str, "Grid", keys, err)
+				}
+				s105 := make([][]time.Duration, 0, len(tokens103))
+				for i106, tok104 := range tokens103 {
+					var el107 []time.Duration
+					tokens108, err := envconfigrt.SplitCollection(tok104, "/;", 1)
+					if err != nil {
+						return envconfigrt.WrapElementParseError(tok104, fmt.Sprintf("Grid[%d]", i106), keys, err)
+					}
+					s110 := make([]time.Duration, 0, len(tokens108))
+					for i111, tok109 := range tokens108 {
+						var el112 time.Duration
+						if err := func() error {
+							v, err := envconfigrt.ParseDuration(tok109)
+							if err != nil {
+								return err
+							}
+							el112 = time.Duration(v)
+							return nil
+						}(); err != nil {
+							return envconfigrt.WrapElementParseError(tok109, fmt.Sprintf("Grid[%d][%d]", i106, i111), keys, err)
+						}
+						s110 = append(s110, el112)
+					}
+					el107 = s110
+					s105 = append(s105, el107)
+				}
+				conf.Grid = s105
+			}
+		}
+
+		return nil
+	}()
+	if err != nil {
+		return Collections{}, err
+	}
+
+	return conf, nil
+}
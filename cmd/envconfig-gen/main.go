@@ -0,0 +1,116 @@
+// Command envconfig-gen generates loaders which populate a configuration struct without using reflection.
+//
+// It's meant to be used with go generate:
+//
+//	//go:generate go run github.com/vrischmann/envconfig/cmd/envconfig-gen -type=Config
+//
+// For each type it generates a function like this:
+//
+//	func LoadConfig(lookup func(string) (string, bool)) (Config, error)
+//
+// The generated function follows the same rules as envconfig.Init: same keys, same tags, same default values,
+// same slice format and the same Unmarshaler support. Pass os.LookupEnv as lookup to read from the environment.
+// The generated code imports the github.com/vrischmann/envconfig/envconfigrt package, which doesn't depend on the
+// reflective envconfig package.
+//
+// It behaves like envconfig.Init with the zero Options, except for the prefix given with -prefix. These Options
+// have no equivalent and are ignored:
+//   - NameMapper: the keys are always the ones of envconfig.FlexibleNames
+//   - AllOptional, LeaveNil and AllowUnexported
+//   - DisallowAmbiguousKeys, DisallowKeyCollisions and DisallowUnknownKeys
+//   - OnKeyCollision: the key collisions are not reported
+//   - DecodeJSON: use the json tag option instead
+//   - ExtendedBools: use the extendedbool tag option instead
+//   - OnDeprecatedKey: the deprecated keys are read but never reported
+//   - Parsers
+//
+// The parsers of envconfig.RegisterParser and the implementations of envconfig.RegisterType are not supported either,
+// and types envconfig can't parse are rejected at generation time.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+var (
+	flTypes  = flag.String("type", "", "comma-separated list of type names; must be set")
+	flPrefix = flag.String("prefix", "", "prefix for each key, like envconfig.Options.Prefix")
+	flOutput = flag.String("output", "", "output file name; default <dir>/<type>_envconfig.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of envconfig-gen:\n")
+	fmt.Fprintf(os.Stderr, "\tenvconfig-gen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("envconfig-gen: ")
+
+	flag.Usage = usage
+	flag.Parse()
+
+	if *flTypes == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	typeNames := strings.Split(*flTypes, ",")
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(pkg.Types, typeNames, *flPrefix)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	output := *flOutput
+	if output == "" {
+		output = strings.ToLower(typeNames[0]) + "_envconfig.go"
+	}
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(dir, output)
+	}
+
+	if err := os.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func loadPackage(dir string) (*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps | packages.NeedFiles,
+		Dir:  dir,
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected exactly one package in %s, got %d", dir, len(pkgs))
+	}
+
+	pkg := pkgs[0]
+	if pkg.Types == nil {
+		return nil, fmt.Errorf("unable to load package in %s: %v", dir, pkg.Errors)
+	}
+
+	return pkg, nil
+}
@@ -0,0 +1,20 @@
+// Command envconfig-vet runs the envconfigcheck analyzer.
+//
+// Run it on your packages like go vet:
+//
+//	go run github.com/vrischmann/envconfig/cmd/envconfig-vet ./...
+//
+// It can also be used as a vet tool:
+//
+//	go vet -vettool=$(which envconfig-vet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/vrischmann/envconfig/analysis/envconfigcheck"
+)
+
+func main() {
+	singlechecker.Main(envconfigcheck.Analyzer)
+}
@@ -0,0 +1,107 @@
+package envconfig
+
+import (
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+// collectionDepth returns the number of nested slices and maps of t, 0 if t is read from a single value.
+func (p parsers) collectionDepth(t reflect.Type) int {
+	switch {
+	case p.isValueType(t), t == byteSliceType:
+		return 0
+	case t.Kind() == reflect.Slice, t.Kind() == reflect.Map:
+		return 1 + p.collectionDepth(t.Elem())
+	default:
+		return 0
+	}
+}
+
+// isSupportedCollection returns true if t is a collection which can be read with the separators seps of the sep
+// tag option: one per level, with supported keys and elements.
+func (p parsers) isSupportedCollection(t reflect.Type, seps string) bool {
+	if seps == "" || p.collectionDepth(t) != utf8.RuneCountInString(seps) {
+		return false
+	}
+
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		if t.Kind() == reflect.Map && !p.isSupportedValueType(t.Key()) {
+			return false
+		}
+		t = t.Elem()
+		if p.collectionDepth(t) == 0 {
+			break
+		}
+	}
+
+	return p.isSupportedValueType(t)
+}
+
+// setCollection sets the collection value from str, level level of the separators of the sep tag option.
+// path is the path of the collection in the errors, like Matrix[2].
+func setCollection(value reflect.Value, str string, level int, path string, ctx *context) error {
+	typ := value.Type()
+
+	split := values.SplitCollection
+	if typ.Kind() == reflect.Map {
+		split = values.SplitMap
+	}
+	tokens, err := split(str, ctx.seps, level)
+	if err != nil {
+		return values.WrapElementParseError(str, path, ctx.keys, err)
+	}
+
+	switch typ.Kind() {
+	case reflect.Map:
+		m := reflect.MakeMapWithSize(typ, len(tokens))
+		for _, token := range tokens {
+			k, v, err := values.SplitMapEntry(token)
+			if err != nil {
+				return values.WrapElementParseError(token, path, ctx.keys, err)
+			}
+
+			elemPath := path + "[" + k + "]"
+
+			key := reflect.New(typ.Key()).Elem()
+			if err := parseElement(key, k, -1, elemPath, ctx); err != nil {
+				return err
+			}
+			elem := reflect.New(typ.Elem()).Elem()
+			if err := parseElement(elem, v, level+1, elemPath, ctx); err != nil {
+				return err
+			}
+
+			m.SetMapIndex(key, elem)
+		}
+		value.Set(m)
+
+	default:
+		slice := reflect.MakeSlice(typ, 0, len(tokens))
+		for i, token := range tokens {
+			elem := reflect.New(typ.Elem()).Elem()
+			if err := parseElement(elem, token, level+1, path+"["+strconv.Itoa(i)+"]", ctx); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		value.Set(slice)
+	}
+
+	return nil
+}
+
+// parseElement parses an element of a collection, which is a collection itself if level is one of the levels of
+// the separators.
+func parseElement(v reflect.Value, str string, level int, path string, ctx *context) error {
+	if level >= 0 && level < utf8.RuneCountInString(ctx.seps) {
+		return setCollection(v, str, level, path, ctx)
+	}
+
+	elemCtx := *ctx
+	elemCtx.path = path
+
+	return parseValue(v, str, &elemCtx)
+}
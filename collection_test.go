@@ -0,0 +1,171 @@
+package envconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+func TestCollectionConfig(t *testing.T) {
+	var conf struct {
+		Matrix  [][]int             `envconfig:"sep=|;"`
+		Cube    [][][]string        `envconfig:"sep=/|;"`
+		Weights []map[string]int    `envconfig:"sep=|;"`
+		Labels  map[string]string   `envconfig:"sep=;"`
+		Links   map[string]string   `envconfig:"sep=;"`
+		Routes  map[string][]int    `envconfig:"sep=;|"`
+		Hosts   []string            `envconfig:"sep=;"`
+		Quoted  [][]string          `envconfig:"sep=|;"`
+		Shards  [][]struct{ N int } `envconfig:"sep=|;"`
+		Default [][]float64         `envconfig:"sep=|;,default=1;2.5|3"`
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"MATRIX":  "1;2;3|4;5|6",
+			"CUBE":    "a;b|c/d",
+			"WEIGHTS": "cpu:2;memory:1|disk:3",
+			"LABELS":  "team:core;url:http://localhost:80",
+			"LINKS":   `url:"http://a;b";"c;d":e;f:g"h`,
+			"ROUTES":  "a:1|2;b:3",
+			"HOSTS":   "a,b;c",
+			"QUOTED":  `"x|y";z\;w|v`,
+			"SHARDS":  "{1};{2}|{3}",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, [][]int{{1, 2, 3}, {4, 5}, {6}}, conf.Matrix)
+	require.Equal(t, [][][]string{{{"a", "b"}, {"c"}}, {{"d"}}}, conf.Cube)
+	require.Equal(t, []map[string]int{{"cpu": 2, "memory": 1}, {"disk": 3}}, conf.Weights)
+	require.Equal(t, map[string]string{"team": "core", "url": "http://localhost:80"}, conf.Labels)
+	require.Equal(t, map[string]string{"url": "http://a;b", "c;d": "e", "f": `g"h`}, conf.Links)
+	require.Equal(t, map[string][]int{"a": {1, 2}, "b": {3}}, conf.Routes)
+	require.Equal(t, []string{"a,b", "c"}, conf.Hosts)
+	require.Equal(t, [][]string{{"x|y", "z;w"}, {"v"}}, conf.Quoted)
+	require.Equal(t, [][]struct{ N int }{{{1}, {2}}, {{3}}}, conf.Shards)
+	require.Equal(t, [][]float64{{1, 2.5}, {3}}, conf.Default)
+}
+
+func TestCollectionConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		conf interface{}
+		src  envconfig.MapSource
+		err  string
+	}{
+		{
+			"element",
+			&struct {
+				Matrix [][]int `envconfig:"sep=|;"`
+			}{},
+			envconfig.MapSource{"MATRIX": "1;2|3|4;5;x"},
+			`envconfig: unable to parse value "x" of Matrix[2][2] for possible keys [MATRIX matrix]. err=strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			"map value",
+			&struct {
+				Weights []map[string]int `envconfig:"sep=|;"`
+			}{},
+			envconfig.MapSource{"WEIGHTS": "cpu:2|memory:lots"},
+			`envconfig: unable to parse value "lots" of Weights[1][memory] for possible keys [WEIGHTS weights]. err=strconv.ParseInt: parsing "lots": invalid syntax`,
+		},
+		{
+			"map entry",
+			&struct {
+				Weights []map[string]int `envconfig:"sep=|;"`
+			}{},
+			envconfig.MapSource{"WEIGHTS": "cpu:2|memory"},
+			`envconfig: unable to parse value "memory" of Weights[1] for possible keys [WEIGHTS weights]. err=map entry "memory" must be written key:value`,
+		},
+		{
+			"tokens",
+			&struct {
+				Matrix [][]string `envconfig:"sep=|;"`
+			}{},
+			envconfig.MapSource{"MATRIX": `a|"b`},
+			`envconfig: unable to parse value "a|\"b" of Matrix for possible keys [MATRIX matrix]. err=missing closing quote for the quote at offset 2`,
+		},
+		{
+			"levels",
+			&struct {
+				Matrix [][]int `envconfig:"sep=;"`
+			}{},
+			envconfig.MapSource{"MATRIX": "1;2"},
+			`envconfig: the sep option ";" of field Matrix doesn't match its type [][]int, use one separator per level`,
+		},
+		{
+			"not a collection",
+			&struct {
+				S string `envconfig:"sep=;"`
+			}{},
+			envconfig.MapSource{"S": "a;b"},
+			`envconfig: the sep option ";" of field S doesn't match its type string, use one separator per level`,
+		},
+		{
+			"comma",
+			&struct {
+				Matrix [][]int `envconfig:"sep=,;"`
+			}{},
+			envconfig.MapSource{"MATRIX": "1;2"},
+			`envconfig: no separator in "sep=", a comma can't be a separator in the envconfig tag of field Matrix`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := envconfig.InitWithOptions(tc.conf, envconfig.Options{Source: tc.src})
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestCollectionConfigErrorsPrefix(t *testing.T) {
+	// the fields are named without the prefix, like in the other errors
+	var conf struct {
+		Database struct {
+			Matrix [][]int `envconfig:"sep=|;"`
+			Hosts  string  `envconfig:"sep=;"`
+		}
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Prefix: "APP",
+		Source: envconfig.MapSource{"APP_DATABASE_MATRIX": "1|x"},
+	})
+	require.EqualError(t, err, `envconfig: unable to parse value "x" of Database.Matrix[1][0] for possible keys [APP_DATABASE_MATRIX app_database_matrix]. err=strconv.ParseInt: parsing "x": invalid syntax`)
+
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{
+		Prefix: "APP",
+		Source: envconfig.MapSource{"APP_DATABASE_MATRIX": "1", "APP_DATABASE_HOSTS": "a"},
+	})
+	require.EqualError(t, err, `envconfig: the sep option ";" of field Database.Hosts doesn't match its type string, use one separator per level`)
+}
+
+func TestCollectionConfigIndexed(t *testing.T) {
+	var conf struct {
+		Matrix [][]int `envconfig:"indexed"`
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"MATRIX_0": "1,2",
+			"MATRIX_1": "3",
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]int{{1, 2}, {3}}, conf.Matrix)
+}
+
+func TestCollectionConfigJSON(t *testing.T) {
+	var conf struct {
+		Weights []map[string]int `envconfig:"json"`
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{"WEIGHTS": `[{"cpu":2},{"disk":3}]`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []map[string]int{{"cpu": 2}, {"disk": 3}}, conf.Weights)
+}
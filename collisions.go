@@ -0,0 +1,153 @@
+package envconfig
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+)
+
+// KeyCollision describes two fields which resolve to the same keys.
+type KeyCollision struct {
+	// Keys are the keys used by both fields.
+	Keys []string
+	// Fields are the field chains of both fields, for example Cassandra.SSLCert and CassandraSSLCert.
+	Fields [2]string
+}
+
+// KeyCollisionError is the error returned when fields resolve to the same keys.
+type KeyCollisionError struct {
+	Collisions []KeyCollision
+}
+
+func (e *KeyCollisionError) Error() string {
+	var buf strings.Builder
+
+	buf.WriteString("envconfig: key collision:")
+	for i, c := range e.Collisions {
+		if i > 0 {
+			buf.WriteString(";")
+		}
+		fmt.Fprintf(&buf, " fields %s and %s both use the keys %s", c.Fields[0], c.Fields[1], strings.Join(c.Keys, ", "))
+	}
+
+	return buf.String()
+}
+
+// CheckKeys builds all the keys of the struct tree of conf and returns a *KeyCollisionError if
+// different fields resolve to the same key.
+//
+// This happens for example with a field Cassandra.SSLCert and a field CassandraSSLCert, which both
+// resolve to CASSANDRA_SSL_CERT, or when a custom name is the same as the key of another field.
+//
+// conf can be a struct or a pointer to a struct; it is not modified.
+func CheckKeys(conf interface{}, opts ...Option) error {
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	typ := reflect.TypeOf(conf)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return ErrInvalidValueKind
+	}
+
+	return getStructPlan(typ, options.Prefix, "", options.NameMapper, newParsers(options.Parsers)).keyCollisionError()
+}
+
+// keyCollisionError returns the collisions of the plan as an error, if any. It is computed once per plan.
+func (p *structPlan) keyCollisionError() error {
+	p.collisionsOnce.Do(func() {
+		var (
+			fields []string                // fields in order
+			keys   = map[string][]string{} // field -> keys
+			owners = map[string]string{}   // key -> first field using it
+		)
+		p.walkKeys("", func(path string, fieldKeys []string) {
+			fields = append(fields, path)
+			keys[path] = fieldKeys
+			for _, key := range fieldKeys {
+				if _, ok := owners[key]; !ok {
+					owners[key] = path
+				}
+			}
+		})
+
+		var collisions []KeyCollision
+		for _, field := range fields {
+			// group the shared keys by the field owning them
+			var (
+				others []string
+				shared = map[string][]string{}
+			)
+			for _, key := range keys[field] {
+				owner := owners[key]
+				if owner == field {
+					continue
+				}
+				if _, ok := shared[owner]; !ok {
+					others = append(others, owner)
+				}
+				shared[owner] = append(shared[owner], key)
+			}
+
+			for _, other := range others {
+				collisions = append(collisions, KeyCollision{
+					Keys:   shared[other],
+					Fields: [2]string{other, field},
+				})
+			}
+		}
+
+		if len(collisions) > 0 {
+			p.collisionsErr = &KeyCollisionError{Collisions: collisions}
+		}
+	})
+
+	return p.collisionsErr
+}
+
+// reportKeyCollisions calls onKeyCollision with each collision of the plan or, if it's nil, logs them once.
+func (p *structPlan) reportKeyCollisions(onKeyCollision func(c KeyCollision)) {
+	err, ok := p.keyCollisionError().(*KeyCollisionError)
+	if !ok {
+		return
+	}
+
+	if onKeyCollision == nil {
+		p.collisionsLogOnce.Do(func() {
+			log.Print(err)
+		})
+		return
+	}
+	for _, c := range err.Collisions {
+		onKeyCollision(c)
+	}
+}
+
+// walkKeys calls fn with the field chain and the keys of each field read from a single value.
+func (p *structPlan) walkKeys(parent string, fn func(path string, keys []string)) {
+	p.walkFields(parent, func(path string, field *fieldPlan) {
+		fn(path, field.keys)
+	})
+}
+
+// walkFields calls fn for every field read from a single value, with its path relative to parent.
+func (p *structPlan) walkFields(parent string, fn func(path string, field *fieldPlan)) {
+	for i := range p.fields {
+		field := &p.fields[i]
+		if field.tag.Skip || field.unexported {
+			continue
+		}
+
+		path := combineName(parent, field.name)
+		if field.sub != nil {
+			field.sub.walkFields(path, fn)
+		} else {
+			fn(path, field)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package envconfig_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type collidingConfig struct {
+	Cassandra struct {
+		SSLCert string
+	}
+	CassandraSSLCert string
+	Name             string `envconfig:"MY_NAME"`
+	MyName           string
+	Other            string
+	Skipped          string `envconfig:"-"`
+	SkippedToo       string `envconfig:"OTHER"`
+}
+
+func TestCheckKeys(t *testing.T) {
+	err := envconfig.CheckKeys(collidingConfig{})
+
+	var collisionErr *envconfig.KeyCollisionError
+	require.True(t, errors.As(err, &collisionErr))
+	require.Equal(t, []envconfig.KeyCollision{
+		{Keys: []string{"CASSANDRA_SSL_CERT", "cassandra_ssl_cert"}, Fields: [2]string{"Cassandra.SSLCert", "CassandraSSLCert"}},
+		{Keys: []string{"MY_NAME"}, Fields: [2]string{"Name", "MyName"}},
+		{Keys: []string{"OTHER"}, Fields: [2]string{"Other", "SkippedToo"}},
+	}, collisionErr.Collisions)
+
+	require.Equal(t, "envconfig: key collision:"+
+		" fields Cassandra.SSLCert and CassandraSSLCert both use the keys CASSANDRA_SSL_CERT, cassandra_ssl_cert;"+
+		" fields Name and MyName both use the keys MY_NAME;"+
+		" fields Other and SkippedToo both use the keys OTHER", err.Error())
+
+	// the prefix doesn't apply to custom names
+	err = envconfig.CheckKeys(&collidingConfig{}, envconfig.WithPrefix("APP"))
+	require.True(t, errors.As(err, &collisionErr))
+	require.Equal(t, 1, len(collisionErr.Collisions))
+	require.Equal(t, [2]string{"Cassandra.SSLCert", "CassandraSSLCert"}, collisionErr.Collisions[0].Fields)
+
+	require.NoError(t, envconfig.CheckKeys(loadedConfig{}))
+	require.Equal(t, envconfig.ErrInvalidValueKind, envconfig.CheckKeys("foobar"))
+}
+
+func TestDisallowKeyCollisions(t *testing.T) {
+	src := envconfig.MapSource{
+		"CASSANDRA_SSL_CERT": "cert",
+		"MY_NAME":            "foobar",
+		"OTHER":              "other",
+	}
+
+	var conf collidingConfig
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, "cert", conf.CassandraSSLCert)
+
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{Source: src, DisallowKeyCollisions: true})
+	var collisionErr *envconfig.KeyCollisionError
+	require.True(t, errors.As(err, &collisionErr))
+
+	_, err = envconfig.Load[collidingConfig](envconfig.WithSource(src), envconfig.DisallowKeyCollisions())
+	require.True(t, errors.As(err, &collisionErr))
+}
+
+func TestReportKeyCollisions(t *testing.T) {
+	type config struct {
+		Name   string `envconfig:"MY_NAME"`
+		MyName string
+	}
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	src := envconfig.MapSource{"MY_NAME": "foobar"}
+
+	// logged once by default
+	for i := 0; i < 2; i++ {
+		var conf config
+		require.NoError(t, envconfig.InitWithOptions(&conf, envconfig.Options{Source: src}))
+	}
+	require.Equal(t, 1, strings.Count(buf.String(), "envconfig: key collision: fields Name and MyName both use the keys MY_NAME"))
+
+	var collisions []envconfig.KeyCollision
+	var conf config
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: src,
+		OnKeyCollision: func(c envconfig.KeyCollision) {
+			collisions = append(collisions, c)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []envconfig.KeyCollision{{Keys: []string{"MY_NAME"}, Fields: [2]string{"Name", "MyName"}}}, collisions)
+}
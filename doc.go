@@ -27,11 +27,17 @@ Then it's just a matter of setting the environment variables when calling your b
 
     ADDR=localhost PORT=6379 AUTH_KEY=foobar ./mybinary
 
+If you have a named type you can also use Load which returns a new value:
+
+    conf, err := envconfig.Load[Config](envconfig.WithPrefix("MYAPP"), envconfig.AllOptional())
+
+Load takes functional options which set the corresponding fields of Options.
+
 Layout of the conf struct
 
 Your conf struct must follow the following rules:
  - no unexported fields by default (can turn off with Options.AllowUnexported)
- - only supported types (no map fields for example)
+ - only supported types (no map fields for example, unless they're decoded from JSON)
 
 Naming of the keys
 
@@ -66,6 +72,22 @@ The default naming scheme takes that and transforms it into the following:
  - CASSANDRA_SSL_CERT, cassandra_ssl_cert, CASSANDRA_SSLCERT, cassandra_sslcert
  - CASSANDRA_SSL_KEY, cassandra_ssl_key, CASSANDRA_SSLKEY, cassandra_sslkey
 
+Words are split on case changes and after digits, and known initialisms like HTTPS or ID are kept together:
+HTTPSProxyURL gives HTTPS_PROXY_URL, S3Bucket gives S3_BUCKET and UserIDs gives USER_IDS.
+See WordSplitter and FlexibleNamesWith to add your own initialisms.
+
+The keys are looked up in this order and the first one set wins. Use Options.DisallowAmbiguousKeys to get an
+error when more than one of them is set with different values.
+
+Other naming schemes are available with Options.NameMapper. The strict ones generate a single key per field,
+which makes lookups cheaper and unambiguous:
+ - ScreamingSnakeNames: CASSANDRA_SSL_CERT
+ - DoubleUnderscoreNames: CASSANDRA__SSL_CERT
+ - KebabNames: cassandra.ssl-cert, for sources which are not the environment
+
+You can also implement your own NameMapper or use SeparatorNames with your own separators. The strict ones keep
+the prefix as given: with the prefix MY_APP, KebabNames looks up MY_APP.cassandra.ssl-cert.
+
 And, if that is not good enough for you, you always have the option to use a custom key:
 
     var conf struct {
@@ -76,6 +98,34 @@ And, if that is not good enough for you, you always have the option to use a cus
 
 Now envconfig will only ever checks the environment variable _cassandraMyName_.
 
+The custom key can also be written name=cassandraMyName. It must be for the keys indexed, json and extendedbool:
+these words used to be key names and are now options, so envconfig:"json" enables the json option while
+envconfig:"name=json" reads the key json. envconfig-vet reports the fields where such an option doesn't fit.
+
+A field can also have several keys, in priority order, with auto standing for the generated keys:
+
+    var conf struct {
+        URL string `envconfig:"names=DATABASE_URL|MYAPP_DB_URL|auto"`
+    }
+
+To rename a key without breaking existing deployments, list the old keys with the deprecated option:
+
+    var conf struct {
+        URL string `envconfig:"name=DATABASE_URL,deprecated=DB_URL|DB_URI"`
+    }
+
+The deprecated keys are only read if DATABASE_URL is not set, and Options.OnDeprecatedKey is called when they are used.
+It's an error to set both DATABASE_URL and a deprecated key with different values.
+
+Different fields can resolve to the same key, for example Cassandra.SSLCert and CassandraSSLCert both
+resolve to CASSANDRA_SSL_CERT. Init logs these collisions, or calls Options.OnKeyCollision if set,
+and Options.DisallowKeyCollisions makes it return an error instead. Use CheckKeys to find them in a test.
+
+When using a prefix, Options.DisallowUnknownKeys makes Init return an error listing the variables starting
+with the prefix which no field uses, with a suggestion for the likely misspelled ones:
+
+    envconfig: unknown keys with prefix MYAPP: MYAPP_DATABSE_URL (did you mean MYAPP_DATABASE_URL?)
+
 
 Content of the variables
 
@@ -104,6 +154,64 @@ Example of a valid struct value:
 Example of a valid slice of struct values:
     {foobar,10,120s},{barbaz,20,50s}
 
+Structs can be nested: {foobar,{localhost,80}}.
+
+To put a comma, a brace, a double quote or a backslash in a value, escape it with a backslash or surround the value
+with double quotes. Other backslashes are kept as is. For example this gives the three values a,b, c,d and C:\data:
+
+    "a,b",c\,d,C:\data
+
+A double quote is only special at the beginning of a value, so foo"bar is kept as is. Unbalanced braces and quotes are
+an error.
+
+Indexed slices
+
+With the indexed option, the elements of a slice are read from one key per element instead:
+
+    var conf struct {
+        Shards []MyStruct `envconfig:"indexed"`
+    }
+
+Here the first element is read from SHARDS_0_NAME, SHARDS_0_ID and SHARDS_0_TIMEOUT, the second one from
+SHARDS_1_NAME and so on, with the usual rules for nested structs. For a slice of strings the keys would be
+HOSTS_0, HOSTS_1 and so on.
+
+Reading stops at the first index with no key set, or at the maximum number of elements given with indexed=N.
+If there's no indexed key at all, the slice is read from a single value as usual.
+
+Each indexed key is read like a single value, so a [][]string can be read from MATRIX_0=a,b and MATRIX_1=c.
+
+Nested slices and maps
+
+With the sep option, slices of slices, maps and slices of maps are read from a single value, with one separator
+per level, outermost first:
+
+    var conf struct {
+        Matrix  [][]int          `envconfig:"sep=|;"`
+        Weights []map[string]int `envconfig:"sep=|;,default=cpu:2|disk:1"`
+    }
+
+    MATRIX='1;2;3|4;5' WEIGHTS='cpu:2;memory:1|disk:3' ./mybinary
+
+Map entries are written key:value. The separators are the same for the default value, and since the tag is split on
+commas a comma can't be one of them, and neither can braces, double quotes or backslashes. Any separator can be
+escaped with a backslash or quoted like in slices, and the value of a map entry can be quoted too: url:"http://a;b".
+Errors give the path of the element, like Matrix[1][0] or Weights[0][memory].
+
+JSON values
+
+With the json option, the value is decoded with encoding/json instead. This is useful for types envconfig can't
+parse, like maps or slices of slices without the sep option:
+
+    var conf struct {
+        Routes map[string][]string `envconfig:"json"`
+    }
+
+    ROUTES='{"eu":["eu-west-1","eu-central-1"]}' ./mybinary
+
+Options.DecodeJSON decodes every field of an unsupported type from JSON, without the json option.
+A JSONError gives the offset of the error in the value.
+
 Special case for bytes slices
 
 For bytes slices, you generally don't want to type out a comma-separated list of byte values.
@@ -120,6 +228,14 @@ Here's an example:
 
 This will decode DATA to FOOBAR and put that into conf.Data.
 
+Use the encoding option for other encodings: base64url (URL-safe, with or without padding), base64raw (without
+padding), hex or raw (the string as is). It also works for byte arrays, which must decode to exactly their size:
+
+    var conf struct {
+        Key   [32]byte `envconfig:"encoding=hex"`
+        Token []byte   `envconfig:"encoding=base64url"`
+    }
+
 Optional values
 
 Sometimes you don't absolutely need a value. Here's how we tell envconfig a value is optional:
@@ -170,10 +286,92 @@ envconfig supports the following list of types:
  - uintX
  - floatX
  - time.Duration
+ - time.Time
+ - *time.Location, from an IANA time zone name like Europe/Paris
+ - url.URL
+ - net.IP, net.IPNet in CIDR notation and net.HardwareAddr
+ - netip.Addr, netip.Prefix and netip.AddrPort
+ - HostPort, an address like localhost:6379
  - pointers to all of the above types
 
 Notably, we don't (yet) support complex types simply because I had no use for it yet.
 
+A value which doesn't fit in the field is an error, for example 300 for an int8.
+
+Integers can have a 0x, 0o or 0b prefix and underscores between digits, like 0xFF_FF or 0o644.
+Without a prefix a leading zero doesn't make the value octal: 0644 is read as 644.
+
+Times
+
+A time.Time value is in RFC 3339 format by default, like 2024-03-01T12:30:00Z. Use the layout option
+to give another layout:
+
+    var conf struct {
+        Cutover  time.Time   `envconfig:"layout=2006-01-02 15:04"`
+        Created  time.Time   `envconfig:"layout=unix"`
+        Holidays []time.Time `envconfig:"layout=DateOnly"`
+        Zone     *time.Location
+    }
+
+The layout is either a layout for time.Parse, the name of one of the layouts of the time package like RFC1123
+or DateOnly, which is the only way to use a layout containing a comma, unix for seconds since the Unix epoch
+or unixms for milliseconds since the Unix epoch.
+
+Booleans
+
+By default a bool value is parsed with strconv.ParseBool. Options.ExtendedBools, or the extendedbool option on a field
+or a struct, also accepts yes/no, y/n, on/off and enabled/disabled, in any case:
+
+    var conf struct {
+        Debug bool `envconfig:"extendedbool"`
+    }
+
+Network addresses
+
+The URL of an url.URL field can be restricted to some schemes with the schemes option:
+
+    var conf struct {
+        Proxy *url.URL `envconfig:"schemes=http|https"`
+    }
+
+Byte sizes
+
+Sizes are easier to get right in human form. A ByteSize field, or an integer field with the unit=bytes option,
+accepts values like 512, 64KiB, 10MB or 1.5GiB:
+
+    var conf struct {
+        BufferSize envconfig.ByteSize
+        CacheSize  int64 `envconfig:"unit=bytes,default=64MiB"`
+    }
+
+The SI suffixes kB, MB, GB, TB, PB and EB are powers of 1000, the IEC suffixes KiB, MiB, GiB, TiB, PiB and EiB
+are powers of 1024. A value which doesn't fit in the field is an error. ByteSize prints back in human form.
+
+Enums
+
+A string or integer field can be restricted to a fixed set of values with the enum option. The value of an integer
+field is the index of the name, or the value given after a colon:
+
+    var conf struct {
+        Level    string `envconfig:"enum=debug|info|warn|error,default=info"`
+        Priority int    `envconfig:"enum=low:-1|normal:0|high:10"`
+    }
+
+A type used in several places can implement Enum instead, here with the constants declared with iota:
+
+    type connectionType uint
+
+    const (
+        tlsConnection connectionType = iota
+        insecureConnection
+    )
+
+    func (connectionType) EnumValues() []string {
+        return []string{"tls", "insecure"}
+    }
+
+Any other value is an error listing the valid ones.
+
 Custom unmarshaler
 
 When the standard types are not enough, you will want to use a custom unmarshaler for your types.
@@ -200,5 +398,83 @@ You do this by implementing Unmarshaler on your type. Here's an example:
         return nil
     }
 
+Custom parsers
+
+For a type you don't own, like a decimal type of another package, register a parse function once before Init:
+
+    func init() {
+        envconfig.RegisterParser(decimal.NewFromString)
+    }
+
+The parse function is used for fields of this type, pointers to it, slices of it and fields of structs in slices.
+To use a parse function for a single call, set Options.Parsers or use WithParser with Load:
+
+    conf, err := envconfig.Load[Config](envconfig.WithParser(decimal.NewFromString))
+
+Loaders generated by envconfig-gen don't use these parse functions.
+
+Pluggable implementations
+
+A field of an interface type is read from the implementations registered with RegisterType:
+
+    func init() {
+        envconfig.RegisterType[StorageBackend]("s3", &S3Storage{})
+        envconfig.RegisterType[StorageBackend]("disk", &DiskStorage{})
+    }
+
+    var conf struct {
+        Storage StorageBackend
+    }
+
+The key of the field name followed by Type, STORAGE_TYPE here, names the implementation. A new value of its type
+is then populated like a nested struct, under the same prefix: with STORAGE_TYPE=s3, S3Storage.Bucket is read from
+STORAGE_BUCKET. The default value of the field is the default implementation, and with the optional option the
+field stays nil if no type is set. Loaders generated by envconfig-gen don't support interface fields.
+
+Sources
+
+By default values are read from the process environment. You can read them from somewhere else with Options.Source:
+
+    src, err := envconfig.NewFileSource("/etc/myapp/app.env")
+    if err != nil {
+        log.Fatalln(err)
+    }
+
+    err = envconfig.InitWithOptions(&conf, envconfig.Options{
+        Source: envconfig.MultiSource{envconfig.EnvSource, src},
+    })
+
+A MultiSource looks up each key in order, so here the environment overrides the file.
+
+Reloading the configuration
+
+A Watcher keeps a configuration and reloads it on demand, on SIGHUP or when a FileSource changes:
+
+    w, err := envconfig.NewWatcher[Config](envconfig.Options{Source: src})
+    if err != nil {
+        log.Fatalln(err)
+    }
+
+    w.Subscribe(func(old, new *Config, changed []string) {
+        log.Printf("configuration changed: %v", changed)
+    })
+
+    go w.Watch(done, 5*time.Second, func(err error) {
+        log.Printf("unable to reload configuration. err=%v", err)
+    })
+
+The new configuration is only swapped in if it loads, and validates if Config has a Validate() error method.
+Use w.Get() to access the current configuration.
+
+Code generation
+
+If you can't or don't want to use reflection, for example with TinyGo, the envconfig-gen command
+generates a loader for a configuration type:
+
+    //go:generate go run github.com/vrischmann/envconfig/cmd/envconfig-gen -type=Config
+
+This generates a function LoadConfig(lookup func(string) (string, bool)) (Config, error) which follows the same rules
+as Init with the default NameMapper. Use it with os.LookupEnv to read from the environment.
+
 */
 package envconfig
@@ -0,0 +1,74 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+// Enum is implemented by string or integer types which have a fixed set of values:
+//
+//	type connectionType uint
+//
+//	const (
+//		tlsConnection connectionType = iota
+//		insecureConnection
+//	)
+//
+//	func (connectionType) EnumValues() []string { return []string{"tls", "insecure"} }
+//
+// Each value is a name optionally followed by a colon and its integer value, like "tls:1". The integer value of
+// a name without one is its index in the values, like for constants declared with iota. For a string type only
+// the names matter. Any other value is an error listing the valid ones.
+type Enum interface {
+	EnumValues() []string
+}
+
+var enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+
+func isEnum(t reflect.Type) bool {
+	return t.Implements(enumType) || reflect.PtrTo(t).Implements(enumType)
+}
+
+// isEnumKind returns true if a value of type t can be restricted to the values of the enum tag option.
+func isEnumKind(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return !isDurationField(t)
+	default:
+		return false
+	}
+}
+
+// parseEnumValue parses a value restricted to the values of the enum tag option or, if not set, of Enum.
+func parseEnumValue(v reflect.Value, str string, ctx *context) error {
+	enumValues := ctx.enum
+	if len(enumValues) == 0 {
+		enumValues = reflect.New(v.Type()).Interface().(Enum).EnumValues()
+	}
+
+	n, err := values.ParseEnum(str, enumValues)
+	if err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(str)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.OverflowInt(n) {
+			return fmt.Errorf("value %d of %q overflows %s", n, str, v.Type())
+		}
+		v.SetInt(n)
+	default:
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return fmt.Errorf("value %d of %q overflows %s", n, str, v.Type())
+		}
+		v.SetUint(uint64(n))
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package envconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type connectionType uint
+
+const (
+	tlsConnection connectionType = iota
+	insecureConnection
+)
+
+func (connectionType) EnumValues() []string { return []string{"tls", "insecure"} }
+
+type logFormat string
+
+func (*logFormat) EnumValues() []string { return []string{"text", "json"} }
+
+func TestEnumConfig(t *testing.T) {
+	var conf struct {
+		Connection  connectionType
+		Connections []connectionType
+		Format      *logFormat
+		Level       string `envconfig:"enum=debug|info|warn|error,default=info"`
+		Priority    int8   `envconfig:"enum=low:-1|normal:0|high:0x10"`
+		Colors      []uint `envconfig:"enum=red|green|blue"`
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"CONNECTION":  "insecure",
+			"CONNECTIONS": "tls,insecure",
+			"FORMAT":      "json",
+			"PRIORITY":    "high",
+			"COLORS":      "blue,red",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, insecureConnection, conf.Connection)
+	require.Equal(t, []connectionType{tlsConnection, insecureConnection}, conf.Connections)
+	require.Equal(t, logFormat("json"), *conf.Format)
+	require.Equal(t, "info", conf.Level)
+	require.Equal(t, int8(16), conf.Priority)
+	require.Equal(t, []uint{2, 0}, conf.Colors)
+}
+
+func TestEnumConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		conf interface{}
+		src  envconfig.MapSource
+		err  string
+	}{
+		{
+			"enum type",
+			&struct{ Connection connectionType }{},
+			envconfig.MapSource{"CONNECTION": "1"},
+			`envconfig: unable to parse value "1" for possible keys [CONNECTION connection]. err=invalid value "1", use one of tls, insecure`,
+		},
+		{
+			"string enum type",
+			&struct{ Format logFormat }{},
+			envconfig.MapSource{"FORMAT": "JSON"},
+			`envconfig: unable to parse value "JSON" for possible keys [FORMAT format]. err=invalid value "JSON", use one of text, json`,
+		},
+		{
+			"tag",
+			&struct {
+				Level string `envconfig:"enum=debug|info"`
+			}{},
+			envconfig.MapSource{"LEVEL": "trace"},
+			`envconfig: unable to parse value "trace" for possible keys [LEVEL level]. err=invalid value "trace", use one of debug, info`,
+		},
+		{
+			"invalid integer value",
+			&struct {
+				Priority int `envconfig:"enum=low:one"`
+			}{},
+			envconfig.MapSource{"PRIORITY": "low"},
+			`envconfig: unable to parse value "low" for possible keys [PRIORITY priority]. err=invalid integer value "one" of "low"`,
+		},
+		{
+			"overflow",
+			&struct {
+				Priority uint8 `envconfig:"enum=low:-1"`
+			}{},
+			envconfig.MapSource{"PRIORITY": "low"},
+			`envconfig: unable to parse value "low" for possible keys [PRIORITY priority]. err=value -1 of "low" overflows uint8`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := envconfig.InitWithOptions(tc.conf, envconfig.Options{Source: tc.src})
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -2,16 +2,15 @@ package envconfig
 
 import (
 	"bytes"
-	"encoding/base64"
 	"errors"
 	"fmt"
-	"os"
 	"reflect"
-	"sort"
-	"strconv"
 	"strings"
 	"time"
 	"unicode"
+
+	"github.com/vrischmann/envconfig/internal/lookup"
+	"github.com/vrischmann/envconfig/internal/values"
 )
 
 var (
@@ -25,12 +24,29 @@ var (
 
 type context struct {
 	name               string
-	customName         string
+	prefix             string // Options.Prefix, which name starts with
+	customNames        []string
+	mapper             NameMapper
+	keys               []string
 	defaultVal         string
 	usingDefault       bool
 	parents            []reflect.Value
 	optional, leaveNil bool
 	allowUnexported    bool
+	disallowAmbiguous  bool
+	decodeJSON         bool
+	extendedBools      bool
+	unit               string
+	layout             string
+	schemes            []string
+	encoding           string
+	enum               []string
+	seps               string
+	path               string // path of the element of a collection being parsed, like Matrix[2][1]
+	parsers            parsers
+	source             Source
+	deprecatedKeys     []string
+	onDeprecatedKey    func(field, oldKey, newKey string)
 }
 
 // Unmarshaler is the interface implemented by objects that can unmarshal
@@ -72,6 +88,52 @@ type Options struct {
 
 	// AllowUnexported allows unexported fields to be present in the passed config.
 	AllowUnexported bool
+
+	// Source is where the values are read from. By default it's EnvSource, the process environment.
+	Source Source
+
+	// DisallowKeyCollisions makes the Init* functions return a *KeyCollisionError if different fields
+	// resolve to the same key. See CheckKeys.
+	DisallowKeyCollisions bool
+
+	// OnKeyCollision is called with each key collision of the configuration struct, unless DisallowKeyCollisions
+	// is set. If nil, the collisions are logged with the log package, once per configuration type.
+	OnKeyCollision func(c KeyCollision)
+
+	// DisallowUnknownKeys makes the Init* functions return an *UnknownKeysError if the source has keys
+	// starting with the prefix which are not used by any field, for example a misspelled MYAPP_DATABSE_URL.
+	//
+	// It is ignored if Prefix is empty. The source must implement KeyLister, which all sources of this package do.
+	DisallowUnknownKeys bool
+
+	// OnDeprecatedKey is called when a value is read from one of the deprecated keys of a field,
+	// set with the deprecated= tag option. field is the field chain without the prefix, like Database.URL,
+	// and newKey is the key which should be used instead.
+	OnDeprecatedKey func(field, oldKey, newKey string)
+
+	// DisallowAmbiguousKeys makes the Init* functions return an error if more than one key of a field is set
+	// with different values, for example CASSANDRA_SSL_CERT and CASSANDRA_SSLCERT.
+	//
+	// Otherwise the first key set wins. The canonical snake case key comes first, followed by its lower case
+	// variant, then the keys without underscores between words.
+	DisallowAmbiguousKeys bool
+
+	// DecodeJSON makes envconfig decode the fields of a type it doesn't support, like maps or slices of slices,
+	// from a JSON value. Use the json tag option to decode a field of a supported type from JSON.
+	DecodeJSON bool
+
+	// ExtendedBools makes envconfig accept yes/no, y/n, on/off and enabled/disabled for bool fields, in any case,
+	// on top of the values accepted by strconv.ParseBool. Use the extendedbool tag option to do it for a single field.
+	ExtendedBools bool
+
+	// NameMapper generates the keys of the fields. By default it's FlexibleNames.
+	//
+	// Use a strict NameMapper like ScreamingSnakeNames to look up a single key per field.
+	NameMapper NameMapper
+
+	// Parsers are parse functions by type, used like the ones registered with RegisterParser but only for this call.
+	// They have priority over the registered ones. A parse function must return a value assignable to its type.
+	Parsers map[reflect.Type]func(string) (any, error)
 }
 
 // Init reads the configuration from environment variables and populates the conf object. conf must be a pointer
@@ -96,66 +158,70 @@ func InitWithOptions(conf interface{}, opts Options) error {
 	elem := value.Elem()
 
 	ctx := context{
-		name:            opts.Prefix,
-		optional:        opts.AllOptional,
-		leaveNil:        opts.LeaveNil,
-		allowUnexported: opts.AllowUnexported,
+		name:              opts.Prefix,
+		prefix:            opts.Prefix,
+		optional:          opts.AllOptional,
+		leaveNil:          opts.LeaveNil,
+		allowUnexported:   opts.AllowUnexported,
+		disallowAmbiguous: opts.DisallowAmbiguousKeys,
+		decodeJSON:        opts.DecodeJSON,
+		extendedBools:     opts.ExtendedBools,
+		parsers:           newParsers(opts.Parsers),
+		source:            opts.Source,
+		onDeprecatedKey:   opts.OnDeprecatedKey,
+		mapper:            opts.NameMapper,
+	}
+	if ctx.source == nil {
+		ctx.source = EnvSource
 	}
+
 	switch elem.Kind() {
 	case reflect.Ptr:
 		if elem.IsNil() {
 			elem.Set(reflect.New(elem.Type().Elem()))
 		}
-		_, err := readStruct(elem.Elem(), &ctx)
-		return err
+		elem = elem.Elem()
+		if elem.Kind() != reflect.Struct {
+			return ErrInvalidValueKind
+		}
 	case reflect.Struct:
-		_, err := readStruct(elem, &ctx)
-		return err
 	default:
 		return ErrInvalidValueKind
 	}
-}
 
-type tag struct {
-	customName string
-	optional   bool
-	skip       bool
-	defaultVal string
-}
-
-func parseTag(s string) *tag {
-	var t tag
-
-	tokens := strings.Split(s, ",")
-	for _, v := range tokens {
-		switch {
-		case v == "-":
-			t.skip = true
-		case v == "optional":
-			t.optional = true
-		case strings.HasPrefix(v, "default="):
-			t.defaultVal = strings.TrimPrefix(v, "default=")
-		default:
-			t.customName = v
+	plan := getStructPlan(elem.Type(), opts.Prefix, "", opts.NameMapper, ctx.parsers)
+	if opts.DisallowKeyCollisions {
+		if err := plan.keyCollisionError(); err != nil {
+			return err
+		}
+	} else {
+		plan.reportKeyCollisions(opts.OnKeyCollision)
+	}
+	if opts.DisallowUnknownKeys && opts.Prefix != "" {
+		if err := checkUnknownKeys(plan, opts.Prefix, ctx.source, opts.NameMapper, ctx.parsers); err != nil {
+			return err
 		}
 	}
 
-	return &t
+	_, err := readStruct(elem, plan, &ctx)
+	return err
 }
 
-func readStruct(value reflect.Value, ctx *context) (nonNil bool, err error) {
+func readStruct(value reflect.Value, plan *structPlan, ctx *context) (nonNil bool, err error) {
+	if plan.err != nil {
+		return false, plan.err
+	}
+
 	var parents []reflect.Value
 
-	for i := 0; i < value.NumField(); i++ {
-		field := value.Field(i)
-		fieldType := field.Type()
-		fieldInfo := value.Type().Field(i)
-		name := fieldInfo.Name
-
-		tag := parseTag(fieldInfo.Tag.Get("envconfig"))
-		if tag.skip || !field.CanSet() {
-			if !field.CanSet() && !ctx.allowUnexported {
-				return false, fmt.Errorf("%w %q", ErrUnexportedField, name)
+	for i := range plan.fields {
+		fieldPlan := &plan.fields[i]
+		field := value.Field(fieldPlan.index)
+		tag := fieldPlan.tag
+
+		if tag.Skip || fieldPlan.unexported {
+			if fieldPlan.unexported && !ctx.allowUnexported {
+				return false, fmt.Errorf("%w %q", ErrUnexportedField, fieldPlan.name)
 			}
 			continue
 		}
@@ -164,7 +230,15 @@ func readStruct(value reflect.Value, ctx *context) (nonNil bool, err error) {
 
 	doRead:
 		switch {
-		case field.Kind() == reflect.Ptr && !isUnmarshaler(fieldType):
+		case tag.JSON || (ctx.decodeJSON && fieldPlan.unsupported):
+			var ok bool
+			ok, err = readJSON(field, ctx.child(fieldPlan, parents))
+			nonNil = nonNil || ok
+		case fieldPlan.iface:
+			var ok bool
+			ok, err = readInterface(field, fieldPlan, ctx.child(fieldPlan, parents))
+			nonNil = nonNil || ok
+		case field.Kind() == reflect.Ptr && !ctx.parsers.isValueType(field.Type()):
 			// it's a pointer, create a new value and restart the switch
 			if field.IsNil() {
 				field.Set(reflect.New(field.Type().Elem()))
@@ -172,28 +246,17 @@ func readStruct(value reflect.Value, ctx *context) (nonNil bool, err error) {
 			}
 			field = field.Elem()
 			goto doRead
-		case field.Kind() == reflect.Struct && !isUnmarshaler(fieldType):
+		case field.Kind() == reflect.Struct && !ctx.parsers.isValueType(field.Type()):
 			var nonNilIn bool
-			nonNilIn, err = readStruct(field, &context{
-				name:            combineName(ctx.name, name),
-				optional:        ctx.optional || tag.optional,
-				defaultVal:      tag.defaultVal,
-				parents:         parents,
-				leaveNil:        ctx.leaveNil,
-				allowUnexported: ctx.allowUnexported,
-			})
+			nonNilIn, err = readStruct(field, fieldPlan.sub, ctx.child(fieldPlan, parents))
 			nonNil = nonNil || nonNilIn
+		case fieldPlan.indexed:
+			var ok bool
+			ok, err = readIndexedSlice(field, fieldPlan, ctx.child(fieldPlan, parents))
+			nonNil = nonNil || ok
 		default:
 			var ok bool
-			ok, err = setField(field, &context{
-				name:            combineName(ctx.name, name),
-				customName:      tag.customName,
-				optional:        ctx.optional || tag.optional,
-				defaultVal:      tag.defaultVal,
-				parents:         parents,
-				leaveNil:        ctx.leaveNil,
-				allowUnexported: ctx.allowUnexported,
-			})
+			ok, err = setField(field, ctx.child(fieldPlan, parents))
 			nonNil = nonNil || ok
 		}
 
@@ -223,12 +286,18 @@ func setField(value reflect.Value, ctx *context) (ok bool, err error) {
 		return false, nil
 	}
 
-	isSliceNotUnmarshaler := value.Kind() == reflect.Slice && !isUnmarshaler(value.Type())
+	isSliceNotUnmarshaler := value.Kind() == reflect.Slice && !ctx.parsers.isValueType(value.Type())
 	switch {
+	case ctx.seps != "":
+		if !ctx.parsers.isSupportedCollection(value.Type(), ctx.seps) {
+			return false, fmt.Errorf("envconfig: the sep option %q of field %s doesn't match its type %s, use one separator per level", ctx.seps, ctx.field(), value.Type())
+		}
+		return true, setCollection(value, str, 0, ctx.field(), ctx)
+
 	case isSliceNotUnmarshaler && value.Type() == byteSliceType:
-		err := parseBytesValue(value, str)
+		err := parseBytesValue(value, str, ctx)
 		if err != nil {
-			err = fmt.Errorf("envconfig: unable to parse value %q as bytes for possible keys %v. err=%v", str, makeAllPossibleKeys(ctx), err)
+			err = values.WrapBytesParseError(str, ctx.keys, err)
 		}
 		return true, err
 
@@ -241,19 +310,16 @@ func setField(value reflect.Value, ctx *context) (ok bool, err error) {
 }
 
 func setSliceField(value reflect.Value, str string, ctx *context) error {
-	separator := sliceEnvSeparator
-	if ctx.usingDefault {
-		separator = sliceDefaultSeparator
+	tokens, err := values.SplitSlice(str, ctx.usingDefault)
+	if err != nil {
+		return values.WrapParseError(str, ctx.keys, err)
 	}
 
 	elType := value.Type().Elem()
-	tnz := newSliceTokenizer(str, separator)
 
 	slice := reflect.MakeSlice(value.Type(), 0, value.Cap())
 
-	for tnz.scan() {
-		token := tnz.text()
-
+	for _, token := range tokens {
 		el := reflect.New(elType).Elem()
 
 		if err := parseValue(el, token, ctx); err != nil {
@@ -265,7 +331,7 @@ func setSliceField(value reflect.Value, str string, ctx *context) error {
 
 	value.Set(slice)
 
-	return tnz.Err()
+	return nil
 }
 
 var (
@@ -281,7 +347,7 @@ func isUnmarshaler(t reflect.Type) bool {
 	return t.Implements(unmarshalerType) || reflect.PtrTo(t).Implements(unmarshalerType)
 }
 
-func parseValue(v reflect.Value, str string, ctx *context) (err error) {
+func parseValue(v reflect.Value, str string, ctx *context) error {
 	vtype := v.Type()
 
 	// Special case when the type is a map: we need to make the map
@@ -290,38 +356,25 @@ func parseValue(v reflect.Value, str string, ctx *context) (err error) {
 		v.Set(reflect.MakeMap(vtype))
 	}
 
-	kind := vtype.Kind()
+	parse := ctx.parsers.parserFor(vtype)
 	switch {
-	case isUnmarshaler(vtype):
-		// Special case for Unmarshaler
-		err = parseWithUnmarshaler(v, str)
-	case isDurationField(vtype):
-		// Special case for time.Duration
-		err = parseDuration(v, str)
-	case kind == reflect.Bool:
-		err = parseBoolValue(v, str)
-	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
-		err = parseIntValue(v, str)
-	case kind == reflect.Uint, kind == reflect.Uint8, kind == reflect.Uint16, kind == reflect.Uint32, kind == reflect.Uint64:
-		err = parseUintValue(v, str)
-	case kind == reflect.Float32, kind == reflect.Float64:
-		err = parseFloatValue(v, str)
-	case kind == reflect.Ptr:
+	case parse == nil && vtype.Kind() == reflect.Ptr:
 		v.Set(reflect.New(vtype.Elem()))
 		return parseValue(v.Elem(), str, ctx)
-	case kind == reflect.String:
-		v.SetString(str)
-	case kind == reflect.Struct:
-		err = parseStruct(v, str, ctx)
-	default:
-		return fmt.Errorf("envconfig: kind %v not supported", kind)
+	case parse == nil:
+		return fmt.Errorf("envconfig: kind %v not supported", vtype.Kind())
+	case len(ctx.enum) > 0 && isEnumKind(vtype) && !ctx.parsers.isValueType(vtype):
+		parse = parseEnumValue
 	}
 
-	if err != nil {
-		return fmt.Errorf("envconfig: unable to parse value %q for possible keys %v. err=%v", str, makeAllPossibleKeys(ctx), err)
+	if err := parse(v, str, ctx); err != nil {
+		if ctx.path != "" {
+			return values.WrapElementParseError(str, ctx.path, ctx.keys, err)
+		}
+		return values.WrapParseError(str, ctx.keys, err)
 	}
 
-	return
+	return nil
 }
 
 func parseWithUnmarshaler(v reflect.Value, str string) error {
@@ -338,7 +391,7 @@ func parseWithUnmarshaler(v reflect.Value, str string) error {
 }
 
 func parseDuration(v reflect.Value, str string) error {
-	d, err := time.ParseDuration(str)
+	d, err := values.ParseDuration(str)
 	if err != nil {
 		return err
 	}
@@ -350,14 +403,9 @@ func parseDuration(v reflect.Value, str string) error {
 
 // NOTE(vincent): this is only called when parsing structs inside a slice.
 func parseStruct(value reflect.Value, token string, ctx *context) error {
-	separator := string(sliceEnvSeparator)
-	if ctx.usingDefault {
-		separator = string(sliceDefaultSeparator)
-	}
-
-	tokens := strings.Split(token[1:len(token)-1], separator)
-	if len(tokens) != value.NumField() {
-		return fmt.Errorf("struct token has %d fields but struct has %d", len(tokens), value.NumField())
+	tokens, err := values.SplitStruct(token, ctx.usingDefault, value.NumField())
+	if err != nil {
+		return err
 	}
 
 	for i := 0; i < value.NumField(); i++ {
@@ -372,8 +420,13 @@ func parseStruct(value reflect.Value, token string, ctx *context) error {
 	return nil
 }
 
-func parseBoolValue(v reflect.Value, str string) error {
-	val, err := strconv.ParseBool(str)
+func parseBoolValue(v reflect.Value, str string, ctx *context) error {
+	parse := values.ParseBool
+	if ctx.extendedBools {
+		parse = values.ParseExtendedBool
+	}
+
+	val, err := parse(str)
 	if err != nil {
 		return err
 	}
@@ -382,8 +435,25 @@ func parseBoolValue(v reflect.Value, str string) error {
 	return nil
 }
 
-func parseIntValue(v reflect.Value, str string) error {
-	val, err := strconv.ParseInt(str, 10, 64)
+func parseStringValue(v reflect.Value, str string) error {
+	v.SetString(str)
+
+	return nil
+}
+
+func parseIntValue(v reflect.Value, str string, ctx *context) error {
+	var (
+		val int64
+		err error
+	)
+	switch ctx.unit {
+	case "":
+		val, err = values.ParseInt(str, v.Type().Bits())
+	case unitBytes:
+		val, err = values.ParseIntBytes(str, v.Type().Bits())
+	default:
+		err = unknownUnitError(ctx.unit)
+	}
 	if err != nil {
 		return err
 	}
@@ -392,8 +462,19 @@ func parseIntValue(v reflect.Value, str string) error {
 	return nil
 }
 
-func parseUintValue(v reflect.Value, str string) error {
-	val, err := strconv.ParseUint(str, 10, 64)
+func parseUintValue(v reflect.Value, str string, ctx *context) error {
+	var (
+		val uint64
+		err error
+	)
+	switch ctx.unit {
+	case "":
+		val, err = values.ParseUint(str, v.Type().Bits())
+	case unitBytes:
+		val, err = values.ParseUintBytes(str, v.Type().Bits())
+	default:
+		err = unknownUnitError(ctx.unit)
+	}
 	if err != nil {
 		return err
 	}
@@ -402,8 +483,15 @@ func parseUintValue(v reflect.Value, str string) error {
 	return nil
 }
 
+// unitBytes is the unit of the integer fields read like a ByteSize.
+const unitBytes = "bytes"
+
+func unknownUnitError(unit string) error {
+	return fmt.Errorf("unknown unit %q, the only unit is %q", unit, unitBytes)
+}
+
 func parseFloatValue(v reflect.Value, str string) error {
-	val, err := strconv.ParseFloat(str, 64)
+	val, err := values.ParseFloat(str, v.Type().Bits())
 	if err != nil {
 		return err
 	}
@@ -412,8 +500,8 @@ func parseFloatValue(v reflect.Value, str string) error {
 	return nil
 }
 
-func parseBytesValue(v reflect.Value, str string) error {
-	val, err := base64.StdEncoding.DecodeString(str)
+func parseBytesValue(v reflect.Value, str string, ctx *context) error {
+	val, err := values.ParseBytes(str, ctx.encoding)
 	if err != nil {
 		return err
 	}
@@ -422,6 +510,16 @@ func parseBytesValue(v reflect.Value, str string) error {
 	return nil
 }
 
+func parseByteArrayValue(v reflect.Value, str string, ctx *context) error {
+	val, err := values.ParseByteArray(str, ctx.encoding, v.Len())
+	if err != nil {
+		return err
+	}
+	reflect.Copy(v, reflect.ValueOf(val))
+
+	return nil
+}
+
 func combineName(parentName, name string) string {
 	if parentName == "" {
 		return name
@@ -430,48 +528,121 @@ func combineName(parentName, name string) string {
 	return parentName + "." + name
 }
 
-func readValue(ctx *context) (string, error) {
-	keys := makeAllPossibleKeys(ctx)
+// child returns the context of the field of fieldPlan in the struct of ctx. parents are the pointers allocated to
+// reach the field, which are reset if leaveNil is true and nothing is set.
+func (ctx *context) child(fieldPlan *fieldPlan, parents []reflect.Value) *context {
+	tag := fieldPlan.tag
+
+	return &context{
+		name:              fieldPlan.fullName,
+		prefix:            ctx.prefix,
+		customNames:       tag.CustomNames(),
+		mapper:            ctx.mapper,
+		keys:              fieldPlan.keys,
+		defaultVal:        tag.Default,
+		parents:           parents,
+		optional:          ctx.optional || tag.Optional,
+		leaveNil:          ctx.leaveNil,
+		allowUnexported:   ctx.allowUnexported,
+		disallowAmbiguous: ctx.disallowAmbiguous,
+		decodeJSON:        ctx.decodeJSON,
+		extendedBools:     ctx.extendedBools || tag.ExtendedBool,
+		unit:              tag.Unit,
+		layout:            tag.Layout,
+		schemes:           tag.Schemes,
+		encoding:          tag.Encoding,
+		enum:              tag.Enum,
+		seps:              tag.Sep,
+		parsers:           ctx.parsers,
+		source:            ctx.source,
+		deprecatedKeys:    tag.Deprecated,
+		onDeprecatedKey:   ctx.onDeprecatedKey,
+	}
+}
 
-	var str string
+// field returns the field chain of the context without the prefix, for example Database.URL.
+func (ctx *context) field() string {
+	if ctx.prefix == "" {
+		return ctx.name
+	}
+	return strings.TrimPrefix(ctx.name, ctx.prefix+".")
+}
 
-	for _, key := range keys {
-		str = os.Getenv(key)
-		if str != "" {
-			break
+func readValue(ctx *context) (string, error) {
+	if ctx.disallowAmbiguous {
+		if err := checkAmbiguousKeys(ctx); err != nil {
+			return "", err
 		}
 	}
 
-	if str != "" {
-		return str, nil
+	var onDeprecated func(key string)
+	if ctx.onDeprecatedKey != nil && len(ctx.keys) > 0 {
+		onDeprecated = func(key string) {
+			ctx.onDeprecatedKey(ctx.field(), key, ctx.keys[0])
+		}
 	}
 
-	if ctx.defaultVal != "" {
-		ctx.usingDefault = true
-		return ctx.defaultVal, nil
+	str, usingDefault, err := lookup.Value(ctx.source.Lookup, ctx.keys, ctx.defaultVal, ctx.optional, ctx.deprecatedKeys, onDeprecated)
+	ctx.usingDefault = usingDefault
+
+	return str, err
+}
+
+// checkAmbiguousKeys returns an error naming all keys which are set if some of them have different values.
+func checkAmbiguousKeys(ctx *context) error {
+	var (
+		setKeys   []string
+		str       string
+		ambiguous bool
+	)
+	for _, key := range ctx.keys {
+		v, _ := ctx.source.Lookup(key)
+		if v == "" {
+			continue
+		}
+		if len(setKeys) == 0 {
+			str = v
+		}
+		setKeys = append(setKeys, key)
+		ambiguous = ambiguous || v != str
 	}
 
-	if ctx.optional {
-		return "", nil
+	if ambiguous {
+		return fmt.Errorf("envconfig: keys %s are set with different values", strings.Join(setKeys, ", "))
 	}
 
-	return "", fmt.Errorf("envconfig: keys %s not found", strings.Join(keys, ", "))
+	return nil
+}
+
+func makeAllPossibleKeys(ctx *context) []string {
+	return lookup.Keys(ctx.customNames, func() []string {
+		return mapKeys(ctx)
+	})
 }
 
-func makeAllPossibleKeys(ctx *context) (res []string) {
-	if ctx.customName != "" {
-		return []string{ctx.customName}
+// mapKeys returns the keys generated from the prefix and the field chain by the NameMapper.
+func mapKeys(ctx *context) []string {
+	mapper := ctx.mapper
+	if mapper == nil {
+		mapper = FlexibleNames
 	}
 
+	return mapper.Keys(ctx.prefix, strings.Split(ctx.field(), "."))
+}
+
+// makeGeneratedKeys returns the keys generated from the field chain name, for example Cassandra.SSLCert.
+func makeGeneratedKeys(name string, words *WordSplitter) (res []string) {
 	tmp := make(map[string]struct{})
 	{
-		n := []rune(ctx.name)
+		n := []rune(name)
 
+		// buf is the key generated before the WordSplitter existed. It's still a candidate
+		// so that no key which used to work stops working.
 		var buf bytes.Buffer  // this is the buffer where we put extra underscores on "word" boundaries
 		var buf2 bytes.Buffer // this is the buffer with the standard naming scheme
 
 		wroteUnderscore := false
-		for i, r := range ctx.name {
+		for i, r := range name {
 			if r == '.' {
 				buf.WriteRune('_')
 				buf2.WriteRune('_')
@@ -490,17 +661,23 @@ func makeAllPossibleKeys(ctx *context) (res []string) {
 			wroteUnderscore = false
 		}
 
-		tmp[strings.ToLower(buf.String())] = struct{}{}
-		tmp[strings.ToUpper(buf.String())] = struct{}{}
-		tmp[strings.ToLower(buf2.String())] = struct{}{}
-		tmp[strings.ToUpper(buf2.String())] = struct{}{}
-	}
-
-	for k := range tmp {
-		res = append(res, k)
+		snake := words.snakeCase(strings.Split(name, "."))
+
+		// The order is the precedence of the keys: the canonical snake case key comes first.
+		for _, k := range []string{
+			strings.ToUpper(snake),
+			strings.ToLower(snake),
+			strings.ToUpper(buf.String()),
+			strings.ToLower(buf.String()),
+			strings.ToUpper(buf2.String()),
+			strings.ToLower(buf2.String()),
+		} {
+			if _, ok := tmp[k]; !ok {
+				tmp[k] = struct{}{}
+				res = append(res, k)
+			}
+		}
 	}
 
-	sort.Strings(res)
-
 	return
 }
@@ -60,6 +60,65 @@ func TestParseIntegerConfig(t *testing.T) {
 	require.Equal(t, uint8(2), conf.Version)
 }
 
+func TestParseIntegerOverflow(t *testing.T) {
+	testCases := []struct {
+		key, value string
+		conf       interface{}
+	}{
+		{"INT8_FIELD", "300", &struct{ Int8Field int8 }{}},
+		{"INT8_FIELD", "-129", &struct{ Int8Field int8 }{}},
+		{"UINT16_FIELD", "65536", &struct{ Uint16Field uint16 }{}},
+		{"UINT16_FIELD", "0x1_0000", &struct{ Uint16Field uint16 }{}},
+		{"FLOAT32_FIELD", "1e40", &struct{ Float32Field float32 }{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key+"="+tc.value, func(t *testing.T) {
+			err := envconfig.InitWithOptions(tc.conf, envconfig.Options{
+				Source: envconfig.MapSource{tc.key: tc.value},
+			})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "value out of range")
+		})
+	}
+}
+
+func TestParseIntegerBases(t *testing.T) {
+	var conf struct {
+		Mode    uint32
+		Mask    uint64
+		Flags   uint8
+		Offset  int16
+		Count   int
+		Legacy  int
+		Rate    float64
+		MaxSize int64
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"MODE":     "0o644",
+			"MASK":     "0xFFFF_0000",
+			"FLAGS":    "0b1010",
+			"OFFSET":   "-0x10",
+			"COUNT":    "1_000_000",
+			"LEGACY":   "0644",
+			"RATE":     "1_000.5",
+			"MAX_SIZE": "+0X7F",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, uint32(0o644), conf.Mode)
+	require.Equal(t, uint64(0xFFFF0000), conf.Mask)
+	require.Equal(t, uint8(10), conf.Flags)
+	require.Equal(t, int16(-16), conf.Offset)
+	require.Equal(t, 1000000, conf.Count)
+	require.Equal(t, 644, conf.Legacy)
+	require.Equal(t, 1000.5, conf.Rate)
+	require.Equal(t, int64(127), conf.MaxSize)
+}
+
 func TestParseBoolConfig(t *testing.T) {
 	var conf struct {
 		DoIt bool
@@ -72,6 +131,60 @@ func TestParseBoolConfig(t *testing.T) {
 	require.Equal(t, true, conf.DoIt)
 }
 
+func TestParseExtendedBoolConfig(t *testing.T) {
+	type config struct {
+		Debug   bool
+		Metrics bool
+		Tracing *bool
+		Flags   []bool
+	}
+
+	src := envconfig.MapSource{
+		"DEBUG":   "yes",
+		"METRICS": "Off",
+		"TRACING": "ENABLED",
+		"FLAGS":   "y,n,on,disabled,1,false",
+	}
+
+	var conf config
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{Source: src})
+	require.EqualError(t, err, `envconfig: unable to parse value "yes" for possible keys [DEBUG debug]. err=strconv.ParseBool: parsing "yes": invalid syntax`)
+
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{Source: src, ExtendedBools: true})
+	require.NoError(t, err)
+	require.True(t, conf.Debug)
+	require.False(t, conf.Metrics)
+	require.True(t, *conf.Tracing)
+	require.Equal(t, []bool{true, false, true, false, true, false}, conf.Flags)
+
+	src["DEBUG"] = "maybe"
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{Source: src, ExtendedBools: true})
+	require.EqualError(t, err, `envconfig: unable to parse value "maybe" for possible keys [DEBUG debug]. err=invalid boolean "maybe", use one of true, false, 1, 0, t, f, yes, no, y, n, on, off, enabled, disabled`)
+}
+
+func TestParseExtendedBoolTag(t *testing.T) {
+	var conf struct {
+		Debug   bool `envconfig:"extendedbool"`
+		Feature struct {
+			Enabled bool
+		} `envconfig:"extendedbool"`
+		Strict bool
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{"DEBUG": "on", "FEATURE_ENABLED": "Yes", "STRICT": "true"},
+	})
+	require.NoError(t, err)
+	require.True(t, conf.Debug)
+	require.True(t, conf.Feature.Enabled)
+	require.True(t, conf.Strict)
+
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{"DEBUG": "on", "FEATURE_ENABLED": "Yes", "STRICT": "yes"},
+	})
+	require.EqualError(t, err, `envconfig: unable to parse value "yes" for possible keys [STRICT strict]. err=strconv.ParseBool: parsing "yes": invalid syntax`)
+}
+
 func TestParseBytesConfig(t *testing.T) {
 	var conf struct {
 		Data []byte
@@ -84,6 +197,96 @@ func TestParseBytesConfig(t *testing.T) {
 	require.Equal(t, []byte("FOOBAR"), conf.Data)
 }
 
+func TestParseBytesEncodings(t *testing.T) {
+	var conf struct {
+		Std    []byte  `envconfig:"encoding=base64"`
+		URL    []byte  `envconfig:"encoding=base64url"`
+		Padded []byte  `envconfig:"encoding=base64url"`
+		Raw    []byte  `envconfig:"encoding=base64raw"`
+		Hex    []byte  `envconfig:"encoding=hex"`
+		Plain  []byte  `envconfig:"encoding=raw"`
+		Key    [4]byte `envconfig:"encoding=hex"`
+		IV     *[3]byte
+		Keys   [][2]byte `envconfig:"encoding=hex"`
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"STD":    "+/8=",
+			"URL":    "-_8",
+			"PADDED": "-_8=",
+			"RAW":    "+/8",
+			"HEX":    "deadbeef",
+			"PLAIN":  "not encoded",
+			"KEY":    "00010203",
+			"IV":     "Rk9P",
+			"KEYS":   "0102,0304",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []byte{0xfb, 0xff}, conf.Std)
+	require.Equal(t, []byte{0xfb, 0xff}, conf.URL)
+	require.Equal(t, []byte{0xfb, 0xff}, conf.Padded)
+	require.Equal(t, []byte{0xfb, 0xff}, conf.Raw)
+	require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, conf.Hex)
+	require.Equal(t, []byte("not encoded"), conf.Plain)
+	require.Equal(t, [4]byte{0, 1, 2, 3}, conf.Key)
+	require.Equal(t, [3]byte{'F', 'O', 'O'}, *conf.IV)
+	require.Equal(t, [][2]byte{{1, 2}, {3, 4}}, conf.Keys)
+}
+
+func TestParseBytesEncodingErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		conf interface{}
+		str  string
+		err  string
+	}{
+		{
+			"hex",
+			&struct {
+				Data []byte `envconfig:"encoding=hex"`
+			}{},
+			"xyz",
+			`envconfig: unable to parse value "xyz" as bytes for possible keys [DATA data]. err=invalid hex value: encoding/hex: invalid byte: U+0078 'x'`,
+		},
+		{
+			"base64url",
+			&struct {
+				Data []byte `envconfig:"encoding=base64url"`
+			}{},
+			"+/8",
+			`envconfig: unable to parse value "+/8" as bytes for possible keys [DATA data]. err=invalid base64url value: illegal base64 data at input byte 0`,
+		},
+		{
+			"unknown",
+			&struct {
+				Data []byte `envconfig:"encoding=base32"`
+			}{},
+			"MZXW6===",
+			`envconfig: unable to parse value "MZXW6===" as bytes for possible keys [DATA data]. err=unknown encoding "base32", use one of base64, base64url, base64raw, hex or raw`,
+		},
+		{
+			"array size",
+			&struct {
+				Data [4]byte `envconfig:"encoding=hex"`
+			}{},
+			"0001",
+			`envconfig: unable to parse value "0001" for possible keys [DATA data]. err=decoded value has 2 bytes, expected 4`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := envconfig.InitWithOptions(tc.conf, envconfig.Options{
+				Source: envconfig.MapSource{"DATA": tc.str},
+			})
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
 func TestParseFloatConfig(t *testing.T) {
 	var conf struct {
 		Delta  float32
@@ -140,7 +343,85 @@ func TestParseStructSliceWrongData(t *testing.T) {
 	os.Setenv("SHARDS", "foobar")
 
 	err := envconfig.Init(&conf)
-	require.Equal(t, `envconfig: unable to parse value "foobar" for possible keys [SHARDS shards]. err=struct token has 1 fields but struct has 2`, err.Error())
+	require.Equal(t, `envconfig: unable to parse value "foobar" for possible keys [SHARDS shards]. err=struct token "foobar" must start with { and end with }`, err.Error())
+
+	os.Setenv("SHARDS", "{foobar}")
+
+	err = envconfig.Init(&conf)
+	require.Equal(t, `envconfig: unable to parse value "{foobar}" for possible keys [SHARDS shards]. err=struct token has 1 fields but struct has 2`, err.Error())
+}
+
+func TestParseEscapedSliceConfig(t *testing.T) {
+	var conf struct {
+		Names  []string
+		Paths  []string
+		Shards []struct {
+			Name  string
+			Inner struct {
+				Addr string
+				Port int
+			}
+		}
+		Defaults []string `envconfig:"default=a\\;b;\"c;d\""`
+	}
+
+	src := envconfig.MapSource{
+		"NAMES":  `"a,b",c\,d,\{e\},"f\"g",h\i`,
+		"PATHS":  `C:\data,D:\logs`,
+		"SHARDS": `{"foo,bar",{localhost,80}},{b\}az,{remote,81}}`,
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a,b", "c,d", "{e}", `f"g`, `h\i`}, conf.Names)
+	require.Equal(t, []string{`C:\data`, `D:\logs`}, conf.Paths)
+	require.Equal(t, 2, len(conf.Shards))
+	require.Equal(t, "foo,bar", conf.Shards[0].Name)
+	require.Equal(t, "localhost", conf.Shards[0].Inner.Addr)
+	require.Equal(t, 80, conf.Shards[0].Inner.Port)
+	require.Equal(t, "b}az", conf.Shards[1].Name)
+	require.Equal(t, 81, conf.Shards[1].Inner.Port)
+	require.Equal(t, []string{"a;b", "c;d"}, conf.Defaults)
+}
+
+func TestParseSliceConfigWithQuotes(t *testing.T) {
+	var conf struct {
+		S       []string
+		Heights []string
+	}
+
+	src := envconfig.MapSource{
+		"S":       `foo"bar,baz`,
+		"HEIGHTS": `6'2",5'11"`,
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, []string{`foo"bar`, "baz"}, conf.S)
+	require.Equal(t, []string{`6'2"`, `5'11"`}, conf.Heights)
+}
+
+func TestParseUnbalancedSliceConfig(t *testing.T) {
+	var conf struct {
+		Names []string
+	}
+
+	testCases := []struct {
+		value string
+		err   string
+	}{
+		{"{a,b", "missing } for the { at offset 0"},
+		{"a,{b,{c}", "missing } for the { at offset 2"},
+		{"a},b", "unexpected } at offset 1"},
+		{`a,"b`, "missing closing quote for the quote at offset 2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			err := envconfig.InitWithOptions(&conf, envconfig.Options{Source: envconfig.MapSource{"NAMES": tc.value}})
+			require.EqualError(t, err, fmt.Sprintf("envconfig: unable to parse value %q for possible keys [NAMES names]. err=%s", tc.value, tc.err))
+		})
+	}
 }
 
 func TestParseStructSliceWrongValue(t *testing.T) {
@@ -181,7 +462,7 @@ func TestParseWrongValues(t *testing.T) {
 	var conf5 struct{ Data []byte }
 	os.Setenv("DATA", "foobar")
 	err = envconfig.Init(&conf5)
-	require.Equal(t, `envconfig: unable to parse value "foobar" as bytes for possible keys [DATA data]. err=illegal base64 data at input byte 4`, err.Error())
+	require.Equal(t, `envconfig: unable to parse value "foobar" as bytes for possible keys [DATA data]. err=invalid base64 value: illegal base64 data at input byte 4`, err.Error())
 }
 
 func TestDurationConfig(t *testing.T) {
@@ -363,6 +644,145 @@ func TestParseCustomNameConfig(t *testing.T) {
 	require.Equal(t, "foobar", conf.Name)
 }
 
+func TestParseExplicitNameConfig(t *testing.T) {
+	var conf struct {
+		Name string `envconfig:"name=customName2,optional"`
+	}
+
+	os.Setenv("customName2", "foobar")
+
+	err := envconfig.Init(&conf)
+	require.NoError(t, err)
+	require.Equal(t, "foobar", conf.Name)
+}
+
+func TestParseMultipleNamesConfig(t *testing.T) {
+	var conf struct {
+		URL  string `envconfig:"names=DATABASE_URL|MYAPP_DB_URL"`
+		Host string `envconfig:"names=DATABASE_HOST|auto"`
+	}
+
+	src := envconfig.MapSource{"MYAPP_DB_URL": "postgres://localhost", "HOST": "localhost"}
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, "postgres://localhost", conf.URL)
+	require.Equal(t, "localhost", conf.Host)
+
+	src["DATABASE_URL"] = "postgres://heroku"
+	src["DATABASE_HOST"] = "heroku"
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, "postgres://heroku", conf.URL)
+	require.Equal(t, "heroku", conf.Host)
+
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{Source: envconfig.MapSource{"MYAPP_DB_URL": "postgres://localhost"}})
+	require.EqualError(t, err, "envconfig: keys DATABASE_HOST, HOST, host not found")
+}
+
+func TestDeprecatedKeys(t *testing.T) {
+	type config struct {
+		URL  string `envconfig:"name=DATABASE_URL,deprecated=DB_URL|DB_URI"`
+		Port int    `envconfig:"deprecated=DB_PORT"`
+	}
+
+	type warning struct{ field, oldKey, newKey string }
+
+	load := func(src envconfig.MapSource) (config, []warning, error) {
+		var (
+			conf     config
+			warnings []warning
+		)
+		err := envconfig.InitWithOptions(&conf, envconfig.Options{
+			Prefix: "APP",
+			Source: src,
+			OnDeprecatedKey: func(field, oldKey, newKey string) {
+				warnings = append(warnings, warning{field, oldKey, newKey})
+			},
+		})
+		return conf, warnings, err
+	}
+
+	conf, warnings, err := load(envconfig.MapSource{"DATABASE_URL": "postgres://new", "APP_PORT": "5432"})
+	require.NoError(t, err)
+	require.Equal(t, config{URL: "postgres://new", Port: 5432}, conf)
+	require.Empty(t, warnings)
+
+	conf, warnings, err = load(envconfig.MapSource{"DB_URI": "postgres://old", "DB_PORT": "5432"})
+	require.NoError(t, err)
+	require.Equal(t, config{URL: "postgres://old", Port: 5432}, conf)
+	require.Equal(t, []warning{
+		{"URL", "DB_URI", "DATABASE_URL"},
+		{"Port", "DB_PORT", "APP_PORT"},
+	}, warnings)
+
+	// the same value under both keys is fine
+	conf, warnings, err = load(envconfig.MapSource{"DATABASE_URL": "postgres://new", "DB_URL": "postgres://new", "APP_PORT": "5432"})
+	require.NoError(t, err)
+	require.Equal(t, "postgres://new", conf.URL)
+	require.Empty(t, warnings)
+
+	_, _, err = load(envconfig.MapSource{"DATABASE_URL": "postgres://new", "DB_URL": "postgres://old"})
+	require.EqualError(t, err, "envconfig: key DATABASE_URL and deprecated key DB_URL are both set with different values")
+
+	_, _, err = load(envconfig.MapSource{"DB_URL": "postgres://old", "DB_URI": "postgres://older"})
+	require.EqualError(t, err, "envconfig: key DB_URL and deprecated key DB_URI are both set with different values")
+
+	_, _, err = load(envconfig.MapSource{})
+	require.EqualError(t, err, "envconfig: keys DATABASE_URL, DB_URL, DB_URI not found")
+}
+
+func TestKeyPrecedence(t *testing.T) {
+	type config struct {
+		Cassandra struct {
+			SSLCert string
+		}
+	}
+
+	src := envconfig.MapSource{
+		"CASSANDRA_SSLCERT":  "sslcert",
+		"cassandra_ssl_cert": "lower",
+		"CASSANDRA_SSL_CERT": "canonical",
+	}
+
+	conf, err := envconfig.Load[config](envconfig.WithSource(src))
+	require.NoError(t, err)
+	require.Equal(t, "canonical", conf.Cassandra.SSLCert)
+
+	delete(src, "CASSANDRA_SSL_CERT")
+	conf, err = envconfig.Load[config](envconfig.WithSource(src))
+	require.NoError(t, err)
+	require.Equal(t, "lower", conf.Cassandra.SSLCert)
+
+	_, err = envconfig.Load[config](envconfig.WithSource(envconfig.MapSource{}))
+	require.EqualError(t, err, "envconfig: keys CASSANDRA_SSL_CERT, cassandra_ssl_cert, CASSANDRA_SSLCERT, cassandra_sslcert not found")
+}
+
+func TestDisallowAmbiguousKeys(t *testing.T) {
+	type config struct {
+		Cassandra struct {
+			SSLCert string
+		}
+	}
+
+	src := envconfig.MapSource{
+		"CASSANDRA_SSL_CERT": "cert",
+		"cassandra_sslcert":  "cert",
+	}
+
+	conf, err := envconfig.Load[config](envconfig.WithSource(src), envconfig.DisallowAmbiguousKeys())
+	require.NoError(t, err)
+	require.Equal(t, "cert", conf.Cassandra.SSLCert)
+
+	src["CASSANDRA_SSLCERT"] = "other"
+	_, err = envconfig.Load[config](envconfig.WithSource(src), envconfig.DisallowAmbiguousKeys())
+	require.EqualError(t, err, "envconfig: keys CASSANDRA_SSL_CERT, CASSANDRA_SSLCERT, cassandra_sslcert are set with different values")
+
+	// the first key wins by default
+	conf, err = envconfig.Load[config](envconfig.WithSource(src))
+	require.NoError(t, err)
+	require.Equal(t, "cert", conf.Cassandra.SSLCert)
+}
+
 func TestParseOptionalStruct(t *testing.T) {
 	var conf struct {
 		Master struct {
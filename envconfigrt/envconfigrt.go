@@ -0,0 +1,156 @@
+// Package envconfigrt contains the functions used by the code generated by envconfig-gen. They implement the same
+// rules as envconfig.Init without using reflection.
+//
+// It's only meant to be used by generated code, use envconfig directly otherwise. It doesn't import envconfig, so
+// that a program using only generated loaders doesn't link it.
+package envconfigrt
+
+import (
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/vrischmann/envconfig/internal/lookup"
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+// ReadValue returns the value of the first key which is set in lookup.
+//
+// If no key is set it returns defaultVal if it's not empty, in which case usingDefault is true.
+// Otherwise it returns an empty string if optional is true, or an error.
+//
+// The deprecated keys are read after keys, like the keys of the deprecated= tag option.
+func ReadValue(lookupFn func(string) (string, bool), keys []string, defaultVal string, optional bool, deprecated ...string) (str string, usingDefault bool, err error) {
+	return lookup.Value(lookupFn, keys, defaultVal, optional, deprecated, nil)
+}
+
+// SplitSlice splits the value of a slice into its elements.
+// usingDefault must be true if the value is the default value of the field.
+func SplitSlice(str string, usingDefault bool) ([]string, error) {
+	return values.SplitSlice(str, usingDefault)
+}
+
+// SplitStruct splits a struct token like {foobar,10} into the values of its fields.
+// usingDefault must be true if the value is the default value of the field.
+func SplitStruct(token string, usingDefault bool, numFields int) ([]string, error) {
+	return values.SplitStruct(token, usingDefault, numFields)
+}
+
+// SplitCollection splits the value str of the level level of a nested collection whose levels are separated by
+// the runes of seps, outermost first, like "|;" for a [][]int written 1;2|3;4.
+func SplitCollection(str, seps string, level int) ([]string, error) {
+	return values.SplitCollection(str, seps, level)
+}
+
+// SplitMap is like SplitCollection for a level which is a map, whose entry values can be quoted too.
+func SplitMap(str, seps string, level int) ([]string, error) {
+	return values.SplitMap(str, seps, level)
+}
+
+// SplitMapEntry splits a map entry like cpu:2 into its key and its value.
+func SplitMapEntry(token string) (key, value string, err error) {
+	return values.SplitMapEntry(token)
+}
+
+// WrapParseError returns the error returned when the value str read from one of keys can't be parsed.
+func WrapParseError(str string, keys []string, err error) error {
+	return values.WrapParseError(str, keys, err)
+}
+
+// WrapElementParseError is like WrapParseError for the element at path of a nested collection, like Matrix[2][1].
+func WrapElementParseError(str, path string, keys []string, err error) error {
+	return values.WrapElementParseError(str, path, keys, err)
+}
+
+// WrapBytesParseError is like WrapParseError for []byte values.
+func WrapBytesParseError(str string, keys []string, err error) error {
+	return values.WrapBytesParseError(str, keys, err)
+}
+
+// WrapJSONError returns the *envconfig.JSONError returned when the JSON value of the field chain name, without the
+// prefix, read from one of keys can't be decoded.
+func WrapJSONError(name string, keys []string, err error) error {
+	return values.WrapJSONError(name, keys, err)
+}
+
+// ParseBool parses a bool value.
+func ParseBool(str string) (bool, error) {
+	return values.ParseBool(str)
+}
+
+// ParseExtendedBool parses a bool value like ParseBool, also accepting yes/no, y/n, on/off and enabled/disabled.
+func ParseExtendedBool(str string) (bool, error) {
+	return values.ParseExtendedBool(str)
+}
+
+// ParseInt parses a signed integer value which fits in bitSize bits, 0 meaning the size of an int.
+func ParseInt(str string, bitSize int) (int64, error) {
+	return values.ParseInt(str, bitSize)
+}
+
+// ParseUint is like ParseInt for unsigned integer values.
+func ParseUint(str string, bitSize int) (uint64, error) {
+	return values.ParseUint(str, bitSize)
+}
+
+// ParseIntBytes parses a size in bytes like 64KiB, like envconfig.ByteSize, which fits in a signed integer of
+// bitSize bits.
+func ParseIntBytes(str string, bitSize int) (int64, error) {
+	return values.ParseIntBytes(str, bitSize)
+}
+
+// ParseUintBytes is like ParseIntBytes for unsigned integer values.
+func ParseUintBytes(str string, bitSize int) (uint64, error) {
+	return values.ParseUintBytes(str, bitSize)
+}
+
+// ParseFloat parses a floating point value which fits in bitSize bits.
+func ParseFloat(str string, bitSize int) (float64, error) {
+	return values.ParseFloat(str, bitSize)
+}
+
+// ParseDuration parses a time.Duration value.
+func ParseDuration(str string) (time.Duration, error) {
+	return values.ParseDuration(str)
+}
+
+// ParseTime parses a time.Time value with the layout of the layout tag option.
+func ParseTime(str, layout string) (time.Time, error) {
+	return values.ParseTime(str, layout)
+}
+
+// ParseLocation parses a *time.Location value from an IANA time zone name like "Europe/Paris".
+func ParseLocation(str string) (*time.Location, error) {
+	return values.ParseLocation(str)
+}
+
+// ParseBytes parses a []byte value in the encoding of the encoding tag option.
+func ParseBytes(str, encoding string) ([]byte, error) {
+	return values.ParseBytes(str, encoding)
+}
+
+// ParseByteArray is like ParseBytes for a [size]byte value.
+func ParseByteArray(str, encoding string, size int) ([]byte, error) {
+	return values.ParseByteArray(str, encoding, size)
+}
+
+// ParseEnum returns the integer value of str, which must be one of the values of the enum tag option or of
+// envconfig.Enum.
+func ParseEnum(str string, enumValues []string) (int64, error) {
+	return values.ParseEnum(str, enumValues)
+}
+
+// ParseURL parses a URL value. If schemes are given, the scheme of the URL must be one of them.
+func ParseURL(str string, schemes ...string) (*url.URL, error) {
+	return values.ParseURL(str, schemes...)
+}
+
+// ParseIP parses a net.IP value.
+func ParseIP(str string) (net.IP, error) {
+	return values.ParseIP(str)
+}
+
+// ParseIPNet parses a net.IPNet value in CIDR notation.
+func ParseIPNet(str string) (*net.IPNet, error) {
+	return values.ParseIPNet(str)
+}
@@ -87,3 +87,23 @@ func ExampleInitWithPrefix() {
 	// <nil>
 	// foobar
 }
+
+func ExampleLoad() {
+	type Config struct {
+		Name string
+		Port int `envconfig:"default=8080"`
+	}
+
+	os.Setenv("BAR_NAME", "foobar")
+
+	conf, err := envconfig.Load[Config](envconfig.WithPrefix("BAR"))
+	if err != nil {
+		fmt.Printf("err=%s\n", err)
+	}
+
+	fmt.Println(conf.Name)
+	fmt.Println(conf.Port)
+	// Output:
+	// foobar
+	// 8080
+}
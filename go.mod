@@ -1,10 +1,19 @@
 module github.com/vrischmann/envconfig
 
-go 1.13
+go 1.23.0
 
 require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/tools v0.33.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/kr/text v0.2.0 // indirect
 	github.com/niemeyer/pretty v0.0.0-20200227124842-a10e7caefd8e // indirect
-	github.com/stretchr/testify v1.10.0
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.24.0 // indirect
+	golang.org/x/sync v0.14.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20200227125254-8fa46927fb4f // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
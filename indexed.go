@@ -0,0 +1,194 @@
+package envconfig
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// readIndexedSlice reads the slice value from indexed keys, like SHARDS_0_NAME, SHARDS_1_NAME and so on for
+// a slice of structs, or HOSTS_0, HOSTS_1 for a slice of strings.
+//
+// It stops at the first index with no key set, or at the maximum index of the tag.
+// If no element is found the slice is read from a single value like any other slice.
+func readIndexedSlice(value reflect.Value, fieldPlan *fieldPlan, ctx *context) (bool, error) {
+	elemType := value.Type().Elem()
+
+	slice := reflect.MakeSlice(value.Type(), 0, value.Cap())
+
+	for i := 0; fieldPlan.tag.MaxIndex <= 0 || i < fieldPlan.tag.MaxIndex; i++ {
+		el := indexedElement{
+			typ:     elemType,
+			prefix:  ctx.prefix,
+			path:    combineName(ctx.field(), strconv.Itoa(i)),
+			mapper:  ctx.mapper,
+			parsers: ctx.parsers,
+		}
+		if !el.exists(ctx.source) {
+			break
+		}
+
+		elem := reflect.New(elemType).Elem()
+		if err := el.read(elem, ctx); err != nil {
+			return false, err
+		}
+
+		slice = reflect.Append(slice, elem)
+	}
+
+	if slice.Len() == 0 {
+		return setField(value, ctx)
+	}
+
+	value.Set(slice)
+
+	return true, nil
+}
+
+// indexedElement is an element of a slice read from indexed keys.
+type indexedElement struct {
+	typ     reflect.Type
+	prefix  string
+	path    string // field chain of the element without the prefix, for example Shards.0
+	mapper  NameMapper
+	parsers parsers
+}
+
+// name returns the field chain of the element including the prefix.
+func (e indexedElement) name() string {
+	return combineName(e.prefix, e.path)
+}
+
+// structType returns the struct type of the element if it's read like a nested struct, or nil.
+func (e indexedElement) structType() reflect.Type {
+	if e.parsers.isValueType(e.typ) {
+		return nil
+	}
+
+	t := e.typ
+	for t.Kind() == reflect.Ptr && !e.parsers.isValueType(t) {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || e.parsers.isValueType(t) {
+		return nil
+	}
+
+	return t
+}
+
+func (e indexedElement) keys() []string {
+	return makeAllPossibleKeys(&context{name: e.name(), prefix: e.prefix, mapper: e.mapper})
+}
+
+// walkKeys calls fn with all the keys of the element.
+func (e indexedElement) walkKeys(fn func(key string)) {
+	st := e.structType()
+	if st == nil {
+		for _, key := range e.keys() {
+			fn(key)
+		}
+		return
+	}
+
+	getStructPlan(st, e.prefix, e.path, e.mapper, e.parsers).walkFields("", func(_ string, field *fieldPlan) {
+		for _, key := range field.keys {
+			fn(key)
+		}
+		for _, key := range field.tag.Deprecated {
+			fn(key)
+		}
+	})
+}
+
+// exists returns true if one of the keys of the element is set.
+func (e indexedElement) exists(src Source) bool {
+	found := false
+	e.walkKeys(func(key string) {
+		if !found {
+			v, _ := src.Lookup(key)
+			found = v != ""
+		}
+	})
+
+	return found
+}
+
+func (e indexedElement) read(elem reflect.Value, ctx *context) error {
+	// the element is read with the options of the slice field, but from its own keys: the default value, custom
+	// names, deprecated keys and parent pointers of the field don't apply to it
+	elemCtx := *ctx
+	elemCtx.name = e.name()
+	elemCtx.prefix = e.prefix
+	elemCtx.keys = nil
+	elemCtx.customNames = nil
+	elemCtx.defaultVal = ""
+	elemCtx.usingDefault = false
+	elemCtx.deprecatedKeys = nil
+	elemCtx.parents = nil
+
+	st := e.structType()
+	if st == nil {
+		elemCtx.keys = e.keys()
+		_, err := setField(elem, &elemCtx)
+		return err
+	}
+
+	for elem.Kind() == reflect.Ptr {
+		elem.Set(reflect.New(elem.Type().Elem()))
+		elem = elem.Elem()
+	}
+
+	_, err := readStruct(elem, getStructPlan(st, e.prefix, e.path, e.mapper, e.parsers), &elemCtx)
+	return err
+}
+
+// walkDynamicKeys calls fn with the keys which depend on the values set in src: the keys of the elements of
+// the indexed slices of the plan and the keys of the types of its interface fields.
+func (p *structPlan) walkDynamicKeys(src Source, mapper NameMapper, parsers parsers, fn func(key string)) {
+	for i := range p.fields {
+		field := &p.fields[i]
+		switch {
+		case field.tag.Skip || field.unexported:
+		case field.sub != nil:
+			field.sub.walkDynamicKeys(src, mapper, parsers, fn)
+		case field.indexed:
+			elemType := field.typ.Elem()
+			for j := 0; field.tag.MaxIndex <= 0 || j < field.tag.MaxIndex; j++ {
+				el := indexedElement{
+					typ:     elemType,
+					prefix:  p.prefix,
+					path:    combineName(field.path, strconv.Itoa(j)),
+					mapper:  mapper,
+					parsers: parsers,
+				}
+				if !el.exists(src) {
+					break
+				}
+				el.walkKeys(fn)
+				if st := el.structType(); st != nil {
+					getStructPlan(st, p.prefix, el.path, mapper, parsers).walkDynamicKeys(src, mapper, parsers, fn)
+				}
+			}
+		case field.iface:
+			var name string
+			for _, key := range field.keys {
+				if name, _ = src.Lookup(key); name != "" {
+					break
+				}
+			}
+			if name == "" {
+				name = field.tag.Default
+			}
+			impl, err := implementation(field.typ, name)
+			if err != nil {
+				break
+			}
+			plan := getStructPlan(implementationStruct(impl), p.prefix, field.path, mapper, parsers)
+			plan.walkKeys("", func(_ string, keys []string) {
+				for _, key := range keys {
+					fn(key)
+				}
+			})
+			plan.walkDynamicKeys(src, mapper, parsers, fn)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package envconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type indexedShard struct {
+	Name     string
+	ID       int
+	Replicas []string `envconfig:"optional"`
+}
+
+type indexedConfig struct {
+	Shards   []indexedShard  `envconfig:"indexed"`
+	Backups  []*indexedShard `envconfig:"indexed,optional"`
+	Hosts    []string        `envconfig:"indexed=2"`
+	Timeouts *[]int          `envconfig:"indexed,default=1;2"`
+}
+
+func TestIndexedSlices(t *testing.T) {
+	src := envconfig.MapSource{
+		"SHARDS_0_NAME":     "foo",
+		"SHARDS_0_ID":       "1",
+		"SHARDS_1_NAME":     "bar",
+		"shards_1_id":       "2",
+		"SHARDS_1_REPLICAS": "a,b",
+		"SHARDS_3_NAME":     "after the gap",
+		"BACKUPS_0_NAME":    "baz",
+		"BACKUPS_0_ID":      "3",
+		"HOSTS_0":           "a,b",
+		"HOSTS_1":           "c",
+		"HOSTS_2":           "after the maximum",
+	}
+
+	conf, err := envconfig.Load[indexedConfig](envconfig.WithSource(src))
+	require.NoError(t, err)
+	require.Equal(t, []indexedShard{
+		{Name: "foo", ID: 1},
+		{Name: "bar", ID: 2, Replicas: []string{"a", "b"}},
+	}, conf.Shards)
+	require.Equal(t, []*indexedShard{{Name: "baz", ID: 3}}, conf.Backups)
+	require.Equal(t, []string{"a,b", "c"}, conf.Hosts)
+	require.Equal(t, []int{1, 2}, *conf.Timeouts)
+}
+
+func TestIndexedSlicesFallback(t *testing.T) {
+	src := envconfig.MapSource{
+		"SHARDS_0_NAME": "foo",
+		"SHARDS_0_ID":   "1",
+		"HOSTS":         "a,b",
+		"TIMEOUTS_0":    "10",
+		"TIMEOUTS_1":    "20",
+		"BACKUPS_ID":    "not an index",
+		"BACKUPS_0ID":   "not an index either",
+	}
+
+	conf, err := envconfig.Load[indexedConfig](envconfig.WithSource(src))
+	require.NoError(t, err)
+	require.Equal(t, []indexedShard{{Name: "foo", ID: 1}}, conf.Shards)
+	require.Nil(t, conf.Backups)
+	require.Equal(t, []string{"a", "b"}, conf.Hosts)
+	require.Equal(t, []int{10, 20}, *conf.Timeouts)
+
+	_, err = envconfig.Load[indexedConfig](envconfig.WithSource(envconfig.MapSource{"HOSTS": "a"}))
+	require.EqualError(t, err, "envconfig: keys SHARDS, shards not found")
+}
+
+func TestIndexedSlicesErrors(t *testing.T) {
+	src := envconfig.MapSource{
+		"SHARDS_0_NAME": "foo",
+		"HOSTS":         "a",
+	}
+
+	_, err := envconfig.Load[indexedConfig](envconfig.WithSource(src))
+	require.EqualError(t, err, "envconfig: keys SHARDS_0_ID, shards_0_id not found")
+
+	src["SHARDS_0_ID"] = "foo"
+	_, err = envconfig.Load[indexedConfig](envconfig.WithSource(src))
+	require.EqualError(t, err, `envconfig: unable to parse value "foo" for possible keys [SHARDS_0_ID shards_0_id]. err=strconv.ParseInt: parsing "foo": invalid syntax`)
+}
+
+func TestIndexedSlicesUnknownKeys(t *testing.T) {
+	src := envconfig.MapSource{
+		"APP_SHARDS_0_NAME": "foo",
+		"APP_SHARDS_0_ID":   "1",
+		"APP_SHARDS_2_NAME": "after the gap",
+		"APP_HOSTS_0":       "a",
+	}
+
+	_, err := envconfig.Load[indexedConfig](
+		envconfig.WithPrefix("APP"),
+		envconfig.WithSource(src),
+		envconfig.DisallowUnknownKeys(),
+	)
+	require.EqualError(t, err, "envconfig: unknown keys with prefix APP: APP_SHARDS_2_NAME")
+}
+
+func TestIndexedSlicesNameMapper(t *testing.T) {
+	src := envconfig.MapSource{
+		"shards.0.name": "foo",
+		"shards.0.id":   "1",
+		"hosts.0":       "a",
+	}
+
+	conf, err := envconfig.Load[indexedConfig](
+		envconfig.WithSource(src),
+		envconfig.WithNameMapper(envconfig.KebabNames),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []indexedShard{{Name: "foo", ID: 1}}, conf.Shards)
+	require.Equal(t, []string{"a"}, conf.Hosts)
+}
+
+func TestIndexedSlicesFieldOptions(t *testing.T) {
+	// the elements are read with the options of the field
+	matrix, err := envconfig.Load[struct {
+		Matrix [][]int `envconfig:"indexed,sep=;"`
+	}](envconfig.WithSource(envconfig.MapSource{"MATRIX_0": "1;2", "MATRIX_1": "3"}))
+	require.NoError(t, err)
+	require.Equal(t, [][]int{{1, 2}, {3}}, matrix.Matrix)
+
+	type shard struct {
+		Name   string
+		Labels map[string]string
+	}
+	shards, err := envconfig.Load[struct {
+		Shards []shard `envconfig:"indexed"`
+	}](
+		envconfig.WithSource(envconfig.MapSource{"SHARDS_0_NAME": "foo", "SHARDS_0_LABELS": `{"team":"core"}`}),
+		envconfig.DecodeJSON(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, []shard{{Name: "foo", Labels: map[string]string{"team": "core"}}}, shards.Shards)
+}
+
+func TestIndexedSliceInvalidMaximum(t *testing.T) {
+	type config struct {
+		Name     string
+		Database struct {
+			Hosts []string `envconfig:"indexed=two"`
+		}
+	}
+
+	_, err := envconfig.Load[config](envconfig.WithPrefix("APP"), envconfig.WithSource(envconfig.MapSource{"APP_NAME": "foo"}))
+	require.EqualError(t, err, `envconfig: invalid maximum index in "indexed=two" in the envconfig tag of field Database.Hosts`)
+
+	_, err = envconfig.Load[struct {
+		Hosts []string `envconfig:"indexed=0"`
+	}](envconfig.WithSource(envconfig.MapSource{"HOSTS_0": "a"}))
+	require.EqualError(t, err, `envconfig: invalid maximum index in "indexed=0" in the envconfig tag of field Hosts`)
+}
@@ -0,0 +1,130 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+// typeKeyName is the name appended to the name of an interface field to make the key of its type,
+// like STORAGE_TYPE for a Storage field.
+const typeKeyName = "Type"
+
+var (
+	implementationsMu sync.Mutex
+	implementations   sync.Map // map[reflect.Type]map[string]reflect.Type, copied on write
+)
+
+// RegisterType registers impl, a struct or a pointer to a struct, as the implementation of the interface I
+// named name:
+//
+//	envconfig.RegisterType[StorageBackend]("s3", &S3Storage{})
+//	envconfig.RegisterType[StorageBackend]("gcs", &GCSStorage{})
+//
+// A field of type I is then read in two steps: its type is read from the key of the field name followed by Type,
+// like STORAGE_TYPE=s3 for a Storage field, then a new value of the type registered with that name is populated
+// like a nested struct, with keys like STORAGE_BUCKET. impl itself is only used for its type.
+//
+// The options of the tag of the field apply to its type: a custom name renames the key of the type and the default
+// value is the default type.
+//
+// RegisterType is meant to be called before Init, in an init function for example. It panics if I is not an interface
+// or if impl is not a struct or a pointer to a struct.
+func RegisterType[I any](name string, impl I) {
+	iface := reflect.TypeOf((*I)(nil)).Elem()
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("envconfig: %s is not an interface", iface))
+	}
+
+	t := reflect.TypeOf(impl)
+	if t == nil || implementationStruct(t) == nil {
+		panic(fmt.Sprintf("envconfig: the implementation %s of %s is not a struct or a pointer to a struct", name, iface))
+	}
+
+	implementationsMu.Lock()
+	defer implementationsMu.Unlock()
+
+	impls := make(map[string]reflect.Type)
+	for k, v := range implementationsOf(iface) {
+		impls[k] = v
+	}
+	impls[name] = t
+	implementations.Store(iface, impls)
+
+	// the cached plans may not know that fields of type I are supported
+	resetCaches()
+}
+
+// implementationsOf returns the implementations of the interface type t by name.
+func implementationsOf(t reflect.Type) map[string]reflect.Type {
+	if impls, ok := implementations.Load(t); ok {
+		return impls.(map[string]reflect.Type)
+	}
+	return nil
+}
+
+func hasImplementations(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface && len(implementationsOf(t)) > 0
+}
+
+// implementationStruct returns the struct type of the implementation type t, or nil if it's not a struct or
+// a pointer to a struct.
+func implementationStruct(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// implementation returns the implementation of the interface type iface named name.
+func implementation(iface reflect.Type, name string) (reflect.Type, error) {
+	impls := implementationsOf(iface)
+	if impl, ok := impls[name]; ok {
+		return impl, nil
+	}
+
+	names := make([]string, 0, len(impls))
+	for k := range impls {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	return nil, fmt.Errorf("unknown type %q, use one of %s", name, strings.Join(names, ", "))
+}
+
+// readInterface reads the type of an interface field from the keys of ctx, then populates a new value of this type.
+func readInterface(value reflect.Value, fieldPlan *fieldPlan, ctx *context) (bool, error) {
+	str, err := readValue(ctx)
+	if err != nil {
+		return false, err
+	}
+	if str == "" {
+		return false, nil
+	}
+
+	impl, err := implementation(value.Type(), str)
+	if err != nil {
+		return false, values.WrapParseError(str, ctx.keys, err)
+	}
+
+	v := reflect.New(impl).Elem()
+	st := v
+	if impl.Kind() == reflect.Ptr {
+		v.Set(reflect.New(impl.Elem()))
+		st = v.Elem()
+	}
+
+	if _, err := readStruct(st, getStructPlan(st.Type(), ctx.prefix, fieldPlan.path, ctx.mapper, ctx.parsers), ctx); err != nil {
+		return false, err
+	}
+	value.Set(v)
+
+	return true, nil
+}
@@ -0,0 +1,114 @@
+package envconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type storageBackend interface {
+	Location() string
+}
+
+type s3Storage struct {
+	Bucket string
+	Region string `envconfig:"default=us-east-1"`
+}
+
+func (s *s3Storage) Location() string { return "s3://" + s.Bucket }
+
+type diskStorage struct {
+	Path string
+}
+
+func (s diskStorage) Location() string { return s.Path }
+
+func init() {
+	envconfig.RegisterType[storageBackend]("s3", &s3Storage{})
+	envconfig.RegisterType[storageBackend]("disk", diskStorage{})
+}
+
+func TestInterfaceConfig(t *testing.T) {
+	var conf struct {
+		Storage storageBackend
+		Backup  storageBackend `envconfig:"default=disk"`
+		Archive storageBackend `envconfig:"optional"`
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Prefix: "APP",
+		Source: envconfig.MapSource{
+			"APP_STORAGE_TYPE":   "s3",
+			"APP_STORAGE_BUCKET": "backups",
+			"APP_BACKUP_PATH":    "/var/backups",
+		},
+		DisallowUnknownKeys: true,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, &s3Storage{Bucket: "backups", Region: "us-east-1"}, conf.Storage)
+	require.Equal(t, diskStorage{Path: "/var/backups"}, conf.Backup)
+	require.Nil(t, conf.Archive)
+}
+
+func TestInterfaceConfigErrors(t *testing.T) {
+	type config struct {
+		Storage storageBackend
+	}
+
+	testCases := []struct {
+		name string
+		src  envconfig.MapSource
+		err  string
+	}{
+		{
+			"missing type",
+			envconfig.MapSource{"STORAGE_BUCKET": "backups"},
+			"envconfig: keys STORAGE_TYPE, storage_type not found",
+		},
+		{
+			"unknown type",
+			envconfig.MapSource{"STORAGE_TYPE": "gcs"},
+			`envconfig: unable to parse value "gcs" for possible keys [STORAGE_TYPE storage_type]. err=unknown type "gcs", use one of disk, s3`,
+		},
+		{
+			"missing field",
+			envconfig.MapSource{"STORAGE_TYPE": "s3"},
+			"envconfig: keys STORAGE_BUCKET, storage_bucket not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := envconfig.Load[config](envconfig.WithSource(tc.src))
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestInterfaceConfigUnknownKeys(t *testing.T) {
+	var conf struct {
+		Storage storageBackend
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Prefix: "APP",
+		Source: envconfig.MapSource{
+			"APP_STORAGE_TYPE":   "disk",
+			"APP_STORAGE_PATH":   "/var/data",
+			"APP_STORAGE_BUCKET": "backups",
+		},
+		DisallowUnknownKeys: true,
+	})
+
+	var unknownErr *envconfig.UnknownKeysError
+	require.ErrorAs(t, err, &unknownErr)
+	require.Len(t, unknownErr.Keys, 1)
+	require.Equal(t, "APP_STORAGE_BUCKET", unknownErr.Keys[0].Key)
+}
+
+func TestRegisterTypePanics(t *testing.T) {
+	require.Panics(t, func() { envconfig.RegisterType[string]("s", "") })
+	require.Panics(t, func() { envconfig.RegisterType[any]("s", 1) })
+}
@@ -0,0 +1,26 @@
+package editdistance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevenshtein(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		exp  int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"optional", "optional", 0},
+		{"optinal", "optional", 1},
+		{"MYAPP_DATABSE_URL", "MYAPP_DATABASE_URL", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.exp, Levenshtein(tc.a, tc.b), "%q/%q", tc.a, tc.b)
+	}
+}
@@ -0,0 +1,160 @@
+// Package gotypesutil contains go/types helpers shared by envconfig-gen and the envconfig analyzer.
+//
+// They mirror the checks envconfig does with reflection.
+package gotypesutil
+
+import (
+	"go/token"
+	"go/types"
+)
+
+// unmarshalerType is the same interface as envconfig.Unmarshaler.
+var unmarshalerType = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(token.NoPos, nil, "Unmarshal", types.NewSignatureType(nil, nil, nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "s", types.Typ[types.String])),
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())),
+		false,
+	)),
+}, nil).Complete()
+
+// enumType is the same interface as envconfig.Enum.
+var enumType = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(token.NoPos, nil, "EnumValues", types.NewSignatureType(nil, nil, nil,
+		nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.NewSlice(types.Typ[types.String]))),
+		false,
+	)),
+}, nil).Complete()
+
+// IsEnum returns true if t is a string or integer type and t or *t implements envconfig.Enum.
+func IsEnum(t types.Type) bool {
+	return IsEnumKind(t) && (types.Implements(t, enumType) || types.Implements(types.NewPointer(t), enumType))
+}
+
+// IsEnumKind returns true if a value of type t can be restricted to the values of the enum tag option:
+// t is a string or an integer other than time.Duration.
+func IsEnumKind(t types.Type) bool {
+	return IsBasic(t, types.IsString|types.IsInteger) && !IsDuration(t)
+}
+
+// IsUnmarshaler returns true if t or *t implements envconfig.Unmarshaler.
+func IsUnmarshaler(t types.Type) bool {
+	return types.Implements(t, unmarshalerType) || types.Implements(types.NewPointer(t), unmarshalerType)
+}
+
+// IsNamed returns true if t is the named type pkgPath.name.
+func IsNamed(t types.Type, pkgPath, name string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+// IsDuration returns true if t is time.Duration.
+func IsDuration(t types.Type) bool {
+	return IsNamed(t, "time", "Duration")
+}
+
+// IsTime returns true if t is time.Time.
+func IsTime(t types.Type) bool {
+	return IsNamed(t, "time", "Time")
+}
+
+// IsLocation returns true if t is *time.Location.
+func IsLocation(t types.Type) bool {
+	ptr, ok := types.Unalias(t).(*types.Pointer)
+	return ok && IsNamed(ptr.Elem(), "time", "Location")
+}
+
+// valueTypes are the struct and slice types of the standard library which envconfig reads from a single value,
+// by package path.
+var valueTypes = map[string][]string{
+	"time":      {"Time"},
+	"net/url":   {"URL"},
+	"net":       {"IP", "IPNet", "HardwareAddr"},
+	"net/netip": {"Addr", "Prefix", "AddrPort"},
+}
+
+// IsValueType returns true if a value of type t is read from a single value even though it's a struct, a pointer
+// or a slice: the Unmarshaler types, *time.Location and the types of the standard library like time.Time or net.IP.
+func IsValueType(t types.Type) bool {
+	if IsUnmarshaler(t) || IsLocation(t) {
+		return true
+	}
+	for path, names := range valueTypes {
+		for _, name := range names {
+			if IsNamed(t, path, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsByteSlice returns true if t is []byte.
+func IsByteSlice(t types.Type) bool {
+	return types.Identical(t, types.NewSlice(types.Typ[types.Byte]))
+}
+
+// IsByteArray returns true if the underlying type of t is an array of bytes, like [32]byte.
+func IsByteArray(t types.Type) bool {
+	a, ok := t.Underlying().(*types.Array)
+	if !ok {
+		return false
+	}
+	b, ok := a.Elem().Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Uint8
+}
+
+// IsBasic returns true if the underlying type of t is a basic type with the given info.
+func IsBasic(t types.Type, info types.BasicInfo) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Info()&info != 0
+}
+
+// BitSize returns the size in bits of the numeric basic type t, like the bitSize argument of strconv.ParseInt.
+// It's 0 for int and uint, whose size depends on the platform.
+func BitSize(t types.Type) int {
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return 0
+	}
+
+	switch b.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int64, types.Uint64, types.Float64:
+		return 64
+	default:
+		return 0
+	}
+}
+
+// IsStruct returns true if the underlying type of t is a struct.
+func IsStruct(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+// CollectionDepth returns the number of nested slices and maps of t, 0 if t is read from a single value,
+// like the number of separators of the sep tag option of a field of type t.
+func CollectionDepth(t types.Type) int {
+	if IsValueType(t) || IsByteSlice(t) {
+		return 0
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return 1 + CollectionDepth(u.Elem())
+	case *types.Map:
+		return 1 + CollectionDepth(u.Elem())
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,95 @@
+// Package lookup finds the keys of a field and reads its value. It's shared by envconfig and the envconfigrt
+// package used by the code generated by envconfig-gen.
+package lookup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vrischmann/envconfig/internal/tags"
+)
+
+// Keys returns the keys of a field with the custom names customNames, in order. generated returns the keys
+// generated from the field chain, which are used if there's no custom name and in place of tags.AutoName.
+func Keys(customNames []string, generated func() []string) (res []string) {
+	if len(customNames) == 0 {
+		return generated()
+	}
+
+	seen := make(map[string]struct{})
+	add := func(key string) {
+		if _, ok := seen[key]; !ok && key != "" {
+			seen[key] = struct{}{}
+			res = append(res, key)
+		}
+	}
+
+	for _, name := range customNames {
+		if name != tags.AutoName {
+			add(name)
+			continue
+		}
+		for _, key := range generated() {
+			add(key)
+		}
+	}
+
+	if len(res) == 0 {
+		return generated()
+	}
+
+	return res
+}
+
+// Value returns the value of the first key which is set in lookup.
+//
+// The deprecated keys are read after keys, like the keys of the deprecated= tag option. onDeprecated, if not nil,
+// is called with the deprecated key the value is read from.
+//
+// If no key is set it returns defaultVal if it's not empty, in which case usingDefault is true.
+// Otherwise it returns an empty string if optional is true, or an error.
+func Value(lookup func(string) (string, bool), keys []string, defaultVal string, optional bool, deprecated []string, onDeprecated func(key string)) (str string, usingDefault bool, err error) {
+	var strKey string
+
+	for _, key := range keys {
+		str, _ = lookup(key)
+		if str != "" {
+			strKey = key
+			break
+		}
+	}
+
+	// deprecated keys are only read if no other key is set, and must not contradict the value read.
+	for _, key := range deprecated {
+		old, _ := lookup(key)
+		switch {
+		case old == "":
+			continue
+		case str == "":
+			str, strKey = old, key
+			if onDeprecated != nil {
+				onDeprecated(key)
+			}
+		case old != str:
+			return "", false, fmt.Errorf("envconfig: key %s and deprecated key %s are both set with different values", strKey, key)
+		}
+	}
+
+	if str != "" {
+		return str, false, nil
+	}
+
+	if defaultVal != "" {
+		return defaultVal, true, nil
+	}
+
+	if optional {
+		return "", false, nil
+	}
+
+	if len(deprecated) > 0 {
+		keys = append(keys[:len(keys):len(keys)], deprecated...)
+	}
+
+	return "", false, fmt.Errorf("envconfig: keys %s not found", strings.Join(keys, ", "))
+}
@@ -0,0 +1,119 @@
+// Package tags parses the envconfig struct tags. It's shared by envconfig, envconfig-gen and the analyzer.
+package tags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Tag is the parsed content of an envconfig struct tag.
+type Tag struct {
+	// Name is the custom key, if any.
+	Name string
+	// Names are the custom keys in priority order, if any. The name "auto" stands for the generated keys.
+	Names []string
+	// Optional is true if the field is optional.
+	Optional bool
+	// Skip is true if the field must be skipped.
+	Skip bool
+	// Default is the default value, if any.
+	Default string
+	// Deprecated are the old keys of the field, which are still read after the other keys.
+	Deprecated []string
+	// Indexed is true if the elements of a slice are read from indexed keys like SHARDS_0_NAME.
+	Indexed bool
+	// MaxIndex is the maximum number of elements read from indexed keys, 0 means no maximum.
+	MaxIndex int
+	// JSON is true if the value is decoded from JSON.
+	JSON bool
+	// Unit is the unit of an integer field. The only unit is "bytes": the value is read like a ByteSize.
+	Unit string
+	// Layout is the layout of a time.Time field, see values.ParseTime.
+	Layout string
+	// Schemes are the allowed schemes of an url.URL field.
+	Schemes []string
+	// ExtendedBool is true if a bool field accepts the words of values.ParseExtendedBool.
+	ExtendedBool bool
+	// Encoding is the encoding of a []byte or [N]byte field, see values.ParseBytes.
+	Encoding string
+	// Enum are the allowed values of a string or integer field, see values.ParseEnum.
+	Enum []string
+	// Sep are the separators of the levels of a nested collection field, outermost first, see values.SplitCollection.
+	Sep string
+}
+
+// Parse parses the content of an envconfig struct tag. It returns an error if the value of an option is invalid,
+// along with the rest of the tag.
+func Parse(s string) (Tag, error) {
+	var (
+		t   Tag
+		err error
+	)
+
+	tokens := strings.Split(s, ",")
+	for _, v := range tokens {
+		switch {
+		case v == "-":
+			t.Skip = true
+		case v == "optional":
+			t.Optional = true
+		case strings.HasPrefix(v, "default="):
+			t.Default = strings.TrimPrefix(v, "default=")
+		case strings.HasPrefix(v, "name="):
+			t.Name = strings.TrimPrefix(v, "name=")
+		case strings.HasPrefix(v, "names="):
+			t.Names = strings.Split(strings.TrimPrefix(v, "names="), "|")
+		case strings.HasPrefix(v, "deprecated="):
+			t.Deprecated = strings.Split(strings.TrimPrefix(v, "deprecated="), "|")
+		case v == "json":
+			t.JSON = true
+		case v == "extendedbool":
+			t.ExtendedBool = true
+		case strings.HasPrefix(v, "encoding="):
+			t.Encoding = strings.TrimPrefix(v, "encoding=")
+		case strings.HasPrefix(v, "unit="):
+			t.Unit = strings.TrimPrefix(v, "unit=")
+		case strings.HasPrefix(v, "layout="):
+			t.Layout = strings.TrimPrefix(v, "layout=")
+		case strings.HasPrefix(v, "schemes="):
+			t.Schemes = strings.Split(strings.TrimPrefix(v, "schemes="), "|")
+		case strings.HasPrefix(v, "sep="):
+			t.Sep = strings.TrimPrefix(v, "sep=")
+			if t.Sep == "" && err == nil {
+				// the tag is split on commas, so sep=,; ends up here
+				err = fmt.Errorf("no separator in %q, a comma can't be a separator", v)
+			}
+		case strings.HasPrefix(v, "enum="):
+			t.Enum = strings.Split(strings.TrimPrefix(v, "enum="), "|")
+		case v == "indexed":
+			t.Indexed = true
+		case strings.HasPrefix(v, "indexed="):
+			t.Indexed = true
+			n, atoiErr := strconv.Atoi(strings.TrimPrefix(v, "indexed="))
+			if (atoiErr != nil || n <= 0) && err == nil {
+				err = fmt.Errorf("invalid maximum index in %q", v)
+			}
+			t.MaxIndex = max(n, 0)
+		default:
+			t.Name = v
+		}
+	}
+
+	return t, err
+}
+
+// AutoName is the custom name which stands for the generated keys in the names= tag option.
+const AutoName = "auto"
+
+// CustomNames returns the custom keys of the field: Names if set, otherwise Name if set.
+func (t Tag) CustomNames() []string {
+	switch {
+	case len(t.Names) > 0:
+		return t.Names
+	case t.Name != "":
+		return []string{t.Name}
+	default:
+		return nil
+	}
+}
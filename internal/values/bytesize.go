@@ -0,0 +1,119 @@
+package values
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ByteUnits are the suffixes of byte sizes and their size in bytes, from the largest to the smallest.
+//
+// SI suffixes are powers of 1000 and IEC suffixes powers of 1024.
+var ByteUnits = []struct {
+	Suffix string
+	Size   uint64
+}{
+	{"EiB", 1 << 60},
+	{"EB", 1e18},
+	{"PiB", 1 << 50},
+	{"PB", 1e15},
+	{"TiB", 1 << 40},
+	{"TB", 1e12},
+	{"GiB", 1 << 30},
+	{"GB", 1e9},
+	{"MiB", 1 << 20},
+	{"MB", 1e6},
+	{"KiB", 1 << 10},
+	{"kB", 1e3},
+	{"B", 1},
+}
+
+// ParseUintBytes parses a size in bytes like 512, 64KiB or 1.5GB which fits in an unsigned integer of bitSize bits,
+// 0 meaning the size of an uint. See ByteUnits for the accepted suffixes, in any case.
+func ParseUintBytes(str string, bitSize int) (uint64, error) {
+	if strings.HasPrefix(str, "-") {
+		return 0, fmt.Errorf("invalid byte size %q: it can't be negative", str)
+	}
+
+	n, err := parseBytes(str)
+	if err != nil {
+		return 0, err
+	}
+
+	max := new(big.Int).Lsh(big.NewInt(1), uint(sizeBits(bitSize)))
+	if n.Cmp(max) >= 0 {
+		return 0, fmt.Errorf("byte size %q is out of range", str)
+	}
+
+	return n.Uint64(), nil
+}
+
+// ParseIntBytes is like ParseUintBytes for signed integers. Negative sizes like -1 are accepted.
+func ParseIntBytes(str string, bitSize int) (int64, error) {
+	neg := strings.HasPrefix(str, "-")
+
+	n, err := parseBytes(strings.TrimPrefix(str, "-"))
+	if err != nil {
+		return 0, err
+	}
+	if neg {
+		n.Neg(n)
+	}
+
+	max := new(big.Int).Lsh(big.NewInt(1), uint(sizeBits(bitSize)-1))
+	if n.Cmp(max) >= 0 || n.Cmp(new(big.Int).Neg(max)) < 0 {
+		return 0, fmt.Errorf("byte size %q is out of range", str)
+	}
+
+	return n.Int64(), nil
+}
+
+func sizeBits(bitSize int) int {
+	if bitSize == 0 {
+		return strconv.IntSize
+	}
+	return bitSize
+}
+
+// parseBytes parses a positive size in bytes.
+func parseBytes(str string) (*big.Int, error) {
+	s := strings.TrimSpace(str)
+
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '_'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	number, suffix := s[:i], strings.TrimSpace(s[i:])
+
+	unit := uint64(1)
+	if suffix != "" {
+		var ok bool
+		if unit, ok = byteUnit(suffix); !ok {
+			return nil, fmt.Errorf("invalid byte size %q: unknown unit %q, use one of B, kB, MB, GB, TB, PB, EB, KiB, MiB, GiB, TiB, PiB or EiB", str, suffix)
+		}
+	}
+
+	r, ok := new(big.Rat).SetString(strings.ReplaceAll(number, "_", ""))
+	if number == "" || strings.HasPrefix(number, "_") || !ok {
+		return nil, fmt.Errorf("invalid byte size %q", str)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(unit)))
+	if !r.IsInt() {
+		return nil, fmt.Errorf("invalid byte size %q: it is not a whole number of bytes", str)
+	}
+
+	return r.Num(), nil
+}
+
+func byteUnit(suffix string) (uint64, bool) {
+	for _, unit := range ByteUnits {
+		if strings.EqualFold(suffix, unit.Suffix) {
+			return unit.Size, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,71 @@
+package values
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// WrapParseError returns the error returned when the value str read from one of keys can't be parsed.
+func WrapParseError(str string, keys []string, err error) error {
+	return fmt.Errorf("envconfig: unable to parse value %q for possible keys %v. err=%v", str, keys, err)
+}
+
+// WrapElementParseError is like WrapParseError for the element at path of a nested collection, like Matrix[2][1].
+func WrapElementParseError(str, path string, keys []string, err error) error {
+	return fmt.Errorf("envconfig: unable to parse value %q of %s for possible keys %v. err=%v", str, path, keys, err)
+}
+
+// WrapBytesParseError is like WrapParseError for []byte values.
+func WrapBytesParseError(str string, keys []string, err error) error {
+	return fmt.Errorf("envconfig: unable to parse value %q as bytes for possible keys %v. err=%v", str, keys, err)
+}
+
+// JSONError is the error returned when a JSON value can't be decoded, envconfig.JSONError is an alias of it.
+type JSONError struct {
+	// Field is the field chain, without the prefix, like Database.URL.
+	Field string
+	// Keys are the keys the value may have been read from.
+	Keys []string
+	// Offset is the offset in the value where the error occurred, or -1 if it's unknown.
+	Offset int64
+	Err    error
+}
+
+func (e *JSONError) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("envconfig: unable to decode JSON value of field %s for possible keys %v. err=%v", e.Field, e.Keys, e.Err)
+	}
+	return fmt.Sprintf("envconfig: unable to decode JSON value of field %s for possible keys %v at offset %d. err=%v", e.Field, e.Keys, e.Offset, e.Err)
+}
+
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+// WrapJSONError returns the *JSONError returned when the JSON value of the field chain field read from one of keys
+// can't be decoded, err being the error returned by encoding/json.
+func WrapJSONError(field string, keys []string, err error) error {
+	return &JSONError{
+		Field:  field,
+		Keys:   keys,
+		Offset: jsonOffset(err),
+		Err:    err,
+	}
+}
+
+// jsonOffset returns the offset in the value of the error err returned by encoding/json, or -1 if it's unknown.
+func jsonOffset(err error) int64 {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		return syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		return typeErr.Offset
+	default:
+		return -1
+	}
+}
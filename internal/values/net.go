@@ -0,0 +1,43 @@
+package values
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ParseURL parses a URL value. If schemes are given, the scheme of the URL must be one of them.
+func ParseURL(str string, schemes ...string) (*url.URL, error) {
+	u, err := url.Parse(str)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schemes) == 0 {
+		return u, nil
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return u, nil
+		}
+	}
+
+	return nil, fmt.Errorf("scheme %q of URL %q is not allowed, use one of %s", u.Scheme, str, strings.Join(schemes, ", "))
+}
+
+// ParseIP parses a net.IP value, either an IPv4 or an IPv6 address.
+func ParseIP(str string) (net.IP, error) {
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", str)
+	}
+	return ip, nil
+}
+
+// ParseIPNet parses a net.IPNet value in CIDR notation, like 10.0.0.0/8. The IP of the result is the network
+// address: 10.1.2.3/8 gives 10.0.0.0/8.
+func ParseIPNet(str string) (*net.IPNet, error) {
+	_, n, err := net.ParseCIDR(str)
+	return n, err
+}
@@ -0,0 +1,216 @@
+package values
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	sliceEnvSeparator     rune = ','
+	sliceDefaultSeparator rune = ';'
+)
+
+// sliceTokenizer splits a slice value into its elements.
+//
+// Separators inside braces don't split, so struct values like {foo,1},{bar,2} give two elements. Braces can be
+// nested. A separator, brace, double quote or backslash preceded by a backslash is a plain character, and so is
+// any character inside double quotes: "a,b",c\,d gives the two elements a,b and c,d. A double quote only opens
+// a quoted element at the start of an element, elsewhere it's a plain character: foo"bar is read as is.
+//
+// If entries is true the elements are map entries, and the value of an entry can be quoted too: url:"http://a;b".
+//
+// Elements starting with a brace are struct values: they are returned as is, to be split again by SplitStruct.
+// The other elements are returned without their quotes and escaping backslashes, unless raw is true.
+type sliceTokenizer struct {
+	err       error
+	r         *strings.Reader
+	separator rune
+	buf       bytes.Buffer
+	offset    int // offset of the next rune
+	done      bool
+
+	// escapable are the separators of the other levels of a nested collection, which can be escaped too.
+	escapable string
+	// raw is true if the elements are split again, as the elements of a nested collection.
+	raw bool
+	// entries is true if the elements are map entries like key:value.
+	entries bool
+}
+
+var eof = rune(0)
+
+func newSliceTokenizer(str string, separator rune) *sliceTokenizer {
+	return &sliceTokenizer{
+		r:         strings.NewReader(str),
+		separator: separator,
+	}
+}
+
+func (t *sliceTokenizer) scan() bool {
+	if t.done {
+		return false
+	}
+
+	var (
+		braces  []int // offsets of the opening braces
+		quoteAt = -1  // offset of the opening quote
+		atStart = true
+		inValue bool // true after the separator of a map entry
+	)
+
+	for {
+		offset := t.offset
+		ch := t.readRune()
+		if ch == eof {
+			t.done = true
+
+			switch {
+			case t.err != io.EOF:
+			case quoteAt >= 0:
+				t.err = fmt.Errorf("missing closing quote for the quote at offset %d", quoteAt)
+			case len(braces) > 0:
+				t.err = fmt.Errorf("missing } for the { at offset %d", braces[len(braces)-1])
+			default:
+				return true
+			}
+			return false
+		}
+
+		start := atStart
+		atStart = false
+
+		switch {
+		case ch == '\\' && t.isEscapable(t.peekRune()):
+			_, _ = t.buf.WriteRune(ch)
+			ch = t.readRune()
+
+		case ch == '"' && quoteAt >= 0:
+			quoteAt = -1
+
+		case quoteAt >= 0:
+
+		case ch == '"' && start:
+			quoteAt = offset
+
+		case ch == '{':
+			braces = append(braces, offset)
+			atStart = true
+
+		case ch == '}':
+			if len(braces) == 0 {
+				t.done = true
+				t.err = fmt.Errorf("unexpected } at offset %d", offset)
+				return false
+			}
+			braces = braces[:len(braces)-1]
+
+		case ch == t.separator && len(braces) == 0:
+			return true
+
+		case t.isEntrySeparator(ch, inValue) && len(braces) == 0:
+			inValue = true
+			atStart = true
+
+		case ch == t.separator, strings.ContainsRune(t.escapable, ch):
+			// the start of an element of a struct or of a nested collection, split again later
+			atStart = true
+		}
+
+		// NOTE(vincent): we ignore the WriteRune error here because there is NO WAY
+		// for WriteRune to return an error.
+		// Yep. Seriously. Look here http://golang.org/src/bytes/buffer.go?s=7661:7714#L227
+		_, _ = t.buf.WriteRune(ch)
+	}
+}
+
+// isEscapable returns true if ch is a character which must be preceded by a backslash to be a plain character.
+func (t *sliceTokenizer) isEscapable(ch rune) bool {
+	return ch == t.separator || ch == '{' || ch == '}' || ch == '"' || ch == '\\' || strings.ContainsRune(t.escapable, ch)
+}
+
+// isEntrySeparator returns true if ch separates the key and the value of a map entry whose value isn't read yet.
+func (t *sliceTokenizer) isEntrySeparator(ch rune, inValue bool) bool {
+	return t.entries && !inValue && string(ch) == MapEntrySeparator
+}
+
+func (t *sliceTokenizer) readRune() rune {
+	ch, size, err := t.r.ReadRune()
+	if err != nil {
+		t.err = err
+		return eof
+	}
+	t.offset += size
+
+	return ch
+}
+
+func (t *sliceTokenizer) peekRune() rune {
+	ch, _, err := t.r.ReadRune()
+	if err != nil {
+		return eof
+	}
+	_ = t.r.UnreadRune()
+
+	return ch
+}
+
+func (t *sliceTokenizer) text() string {
+	str := t.buf.String()
+	t.buf.Reset()
+
+	if t.raw || strings.HasPrefix(str, "{") {
+		return str
+	}
+
+	return t.unquote(str)
+}
+
+// unquote removes the quotes around a token, or around the value of a map entry, and the escaping backslashes.
+// Like in scan, a double quote only opens a quoted section at the start of the token or of the value.
+func (t *sliceTokenizer) unquote(str string) string {
+	if !strings.ContainsAny(str, `"\`) {
+		return str
+	}
+
+	var (
+		buf     strings.Builder
+		runes   = []rune(str)
+		quoted  bool
+		atStart = true
+		inValue bool
+	)
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		start := atStart
+		atStart = false
+
+		switch {
+		case ch == '\\' && i+1 < len(runes) && t.isEscapable(runes[i+1]):
+			i++
+			buf.WriteRune(runes[i])
+		case ch == '"' && quoted:
+			quoted = false
+		case quoted:
+			buf.WriteRune(ch)
+		case ch == '"' && start:
+			quoted = true
+		case t.isEntrySeparator(ch, inValue):
+			inValue = true
+			atStart = true
+			buf.WriteRune(ch)
+		default:
+			buf.WriteRune(ch)
+		}
+	}
+
+	return buf.String()
+}
+
+func (t *sliceTokenizer) Err() error {
+	if t.err == io.EOF {
+		return nil
+	}
+	return t.err
+}
@@ -0,0 +1,160 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSliceTokenizer(t *testing.T) {
+	str := "foobar,barbaz"
+	tnz := newSliceTokenizer(str, sliceEnvSeparator)
+
+	b := tnz.scan()
+	require.Nil(t, tnz.Err())
+	require.Equal(t, true, b)
+
+	require.Equal(t, "foobar", tnz.text())
+
+	b = tnz.scan()
+	require.Nil(t, tnz.Err())
+	require.Equal(t, true, b)
+	require.Equal(t, "barbaz", tnz.text())
+
+	b = tnz.scan()
+	require.Nil(t, tnz.Err())
+	require.Equal(t, false, b)
+}
+
+func TestSliceOfStructsTokenizer(t *testing.T) {
+	str := "{foobar,100},{barbaz,200}"
+	tnz := newSliceTokenizer(str, sliceEnvSeparator)
+
+	b := tnz.scan()
+	require.Nil(t, tnz.Err())
+	require.Equal(t, true, b)
+
+	require.Equal(t, "{foobar,100}", tnz.text())
+
+	b = tnz.scan()
+	require.Nil(t, tnz.Err())
+	require.Equal(t, true, b)
+	require.Equal(t, "{barbaz,200}", tnz.text())
+
+	b = tnz.scan()
+	require.Nil(t, tnz.Err())
+	require.Equal(t, false, b)
+}
+
+func TestSliceTokenizerEscaping(t *testing.T) {
+	testCases := []struct {
+		str    string
+		tokens []string
+	}{
+		{"", []string{""}},
+		{"a,", []string{"a", ""}},
+		{`"a,b",c`, []string{"a,b", "c"}},
+		{`a\,b,c`, []string{"a,b", "c"}},
+		{`\{a\},\"b\"`, []string{"{a}", `"b"`}},
+		{`"{a}",b`, []string{"{a}", "b"}},
+		{`a\\,b`, []string{`a\`, "b"}},
+		{`C:\data,D:\logs`, []string{`C:\data`, `D:\logs`}},
+		{`"a \"b\" c"`, []string{`a "b" c`}},
+		{"{a,{b,c}},{d,{e,f}}", []string{"{a,{b,c}}", "{d,{e,f}}"}},
+		{`{"a,}",b},c`, []string{`{"a,}",b}`, "c"}},
+		{`{a\},b},c`, []string{`{a\},b}`, "c"}},
+		// quotes are only special at the start of an element
+		{`foo"bar,baz`, []string{`foo"bar`, "baz"}},
+		{`6'2",5'11"`, []string{`6'2"`, `5'11"`}},
+		{`"a,b"c",d`, []string{`a,bc"`, "d"}},
+		{`{a"b,"c,d"},e`, []string{`{a"b,"c,d"}`, "e"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.str, func(t *testing.T) {
+			var tokens []string
+
+			tnz := newSliceTokenizer(tc.str, sliceEnvSeparator)
+			for tnz.scan() {
+				tokens = append(tokens, tnz.text())
+			}
+
+			require.NoError(t, tnz.Err())
+			require.Equal(t, tc.tokens, tokens)
+		})
+	}
+}
+
+func TestSliceTokenizerErrors(t *testing.T) {
+	testCases := []struct {
+		str string
+		err string
+	}{
+		{"{a,b", "missing } for the { at offset 0"},
+		{"{a,{b}", "missing } for the { at offset 0"},
+		{"a,{b,{c}", "missing } for the { at offset 2"},
+		{"a},b", "unexpected } at offset 1"},
+		{"{a}},b", "unexpected } at offset 3"},
+		{`a,"b`, "missing closing quote for the quote at offset 2"},
+		{`a,"b\"`, "missing closing quote for the quote at offset 2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.str, func(t *testing.T) {
+			tnz := newSliceTokenizer(tc.str, sliceEnvSeparator)
+			for tnz.scan() {
+			}
+
+			require.EqualError(t, tnz.Err(), tc.err)
+		})
+	}
+}
+
+func TestSplitStruct(t *testing.T) {
+	tokens, err := SplitStruct(`{a\,b,{c,d},"e}"}`, false, 3)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a,b", "{c,d}", "e}"}, tokens)
+
+	tokens, err = SplitStruct("{a;b}", true, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, tokens)
+
+	_, err = SplitStruct("a,b}", false, 2)
+	require.EqualError(t, err, `struct token "a,b}" must start with { and end with }`)
+
+	_, err = SplitStruct("{", false, 1)
+	require.EqualError(t, err, `struct token "{" must start with { and end with }`)
+
+	_, err = SplitStruct("{a,{b}", false, 2)
+	require.EqualError(t, err, "missing } for the { at offset 2")
+}
+
+func TestSplitCollection(t *testing.T) {
+	tokens, err := SplitCollection(`1;2|3\|4`, "|;", 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1;2", `3\|4`}, tokens)
+
+	tokens, err = SplitCollection(`1;"2;3"`, "|;", 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2;3"}, tokens)
+
+	tokens, err = SplitMap(`url:"http://a;b";c:d"e`, ";", 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"url:http://a;b", `c:d"e`}, tokens)
+
+	tokens, err = SplitMap(`a:"1|2";b:3`, ";|", 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{`a:"1|2"`, "b:3"}, tokens)
+
+	_, err = SplitMap(`url:"http://a`, ";", 0)
+	require.EqualError(t, err, "missing closing quote for the quote at offset 4")
+
+	_, err = SplitCollection("1;2", "", 0)
+	require.EqualError(t, err, `no separator for the level 0 of a collection with the separators ""`)
+
+	_, err = SplitCollection("1;2", "|;", 2)
+	require.EqualError(t, err, `no separator for the level 2 of a collection with the separators "|;"`)
+
+	_, err = SplitCollection("1;2", "|;", -1)
+	require.EqualError(t, err, `no separator for the level -1 of a collection with the separators "|;"`)
+}
@@ -0,0 +1,87 @@
+package values
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MapEntrySeparator separates the key and the value of a map entry, like cpu:2.
+const MapEntrySeparator = ":"
+
+// SplitSlice splits the value of a slice into its elements.
+// usingDefault must be true if the value is the default value of the field.
+func SplitSlice(str string, usingDefault bool) ([]string, error) {
+	separator := sliceEnvSeparator
+	if usingDefault {
+		separator = sliceDefaultSeparator
+	}
+
+	var res []string
+
+	tnz := newSliceTokenizer(str, separator)
+	for tnz.scan() {
+		res = append(res, tnz.text())
+	}
+
+	return res, tnz.Err()
+}
+
+// SplitStruct splits a struct token like {foobar,10} into the values of its fields.
+// usingDefault must be true if the value is the default value of the field.
+func SplitStruct(token string, usingDefault bool, numFields int) ([]string, error) {
+	if len(token) < 2 || token[0] != '{' || token[len(token)-1] != '}' {
+		return nil, fmt.Errorf("struct token %q must start with { and end with }", token)
+	}
+
+	tokens, err := SplitSlice(token[1:len(token)-1], usingDefault)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) != numFields {
+		return nil, fmt.Errorf("struct token has %d fields but struct has %d", len(tokens), numFields)
+	}
+
+	return tokens, nil
+}
+
+// SplitCollection splits the value str of the level level of a nested collection whose levels are separated by
+// the runes of seps, outermost first, like "|;" for a [][]int written 1;2|3;4.
+//
+// The elements of all the levels but the last one are returned as is, to be split again.
+func SplitCollection(str, seps string, level int) ([]string, error) {
+	return splitCollection(str, seps, level, false)
+}
+
+// SplitMap is like SplitCollection for a level which is a map: the value of an entry can be quoted too, like
+// url:"http://a;b". Use SplitMapEntry to split the entries.
+func SplitMap(str, seps string, level int) ([]string, error) {
+	return splitCollection(str, seps, level, true)
+}
+
+func splitCollection(str, seps string, level int, entries bool) ([]string, error) {
+	runes := []rune(seps)
+	if level < 0 || level >= len(runes) {
+		return nil, fmt.Errorf("no separator for the level %d of a collection with the separators %q", level, seps)
+	}
+
+	tnz := newSliceTokenizer(str, runes[level])
+	tnz.escapable = seps
+	tnz.raw = level < len(runes)-1
+	tnz.entries = entries
+
+	var res []string
+	for tnz.scan() {
+		res = append(res, tnz.text())
+	}
+
+	return res, tnz.Err()
+}
+
+// SplitMapEntry splits a map entry like cpu:2 into its key and its value.
+func SplitMapEntry(token string) (key, value string, err error) {
+	key, value, ok := strings.Cut(token, MapEntrySeparator)
+	if !ok {
+		return "", "", fmt.Errorf("map entry %q must be written key%svalue", token, MapEntrySeparator)
+	}
+	return key, value, nil
+}
@@ -0,0 +1,68 @@
+package values
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Epoch layouts of time.Time values.
+const (
+	layoutUnix   = "unix"
+	layoutUnixMs = "unixms"
+)
+
+// namedLayouts are the layouts of the time package which can be used by name in the layout tag option.
+// Some of them contain commas so they couldn't be written in a tag.
+var namedLayouts = map[string]string{
+	"Layout":      time.Layout,
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// ParseTime parses a time.Time value with layout, which is either a layout like "2006-01-02", the name of
+// a layout of the time package like "RFC1123", "unix" for seconds since the Unix epoch or "unixms" for
+// milliseconds since the Unix epoch. An empty layout means RFC 3339.
+func ParseTime(str, layout string) (time.Time, error) {
+	switch layout {
+	case "":
+		return time.Parse(time.RFC3339, str)
+
+	case layoutUnix, layoutUnixMs:
+		n, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s time %q", layout, str)
+		}
+		if layout == layoutUnix {
+			return time.Unix(n, 0), nil
+		}
+		return time.UnixMilli(n), nil
+
+	default:
+		if named, ok := namedLayouts[layout]; ok {
+			layout = named
+		}
+		return time.Parse(layout, str)
+	}
+}
+
+// ParseLocation parses a *time.Location value from an IANA time zone name like "Europe/Paris", "UTC" or "Local".
+func ParseLocation(str string) (*time.Location, error) {
+	return time.LoadLocation(str)
+}
@@ -0,0 +1,160 @@
+// Package values parses and splits the values of envconfig fields. It's shared by envconfig, the envconfigrt
+// package used by the code generated by envconfig-gen, and the analyzer, so that they all read values the same way.
+package values
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseBool parses a bool value.
+func ParseBool(str string) (bool, error) {
+	return strconv.ParseBool(str)
+}
+
+// extendedBools are the words accepted by ParseExtendedBool.
+var extendedBools = []struct {
+	word  string
+	value bool
+}{
+	{"true", true}, {"false", false},
+	{"1", true}, {"0", false},
+	{"t", true}, {"f", false},
+	{"yes", true}, {"no", false},
+	{"y", true}, {"n", false},
+	{"on", true}, {"off", false},
+	{"enabled", true}, {"disabled", false},
+}
+
+// ParseExtendedBool parses a bool value like ParseBool, also accepting yes/no, y/n, on/off and enabled/disabled.
+// The case doesn't matter.
+func ParseExtendedBool(str string) (bool, error) {
+	words := make([]string, 0, len(extendedBools))
+	for _, b := range extendedBools {
+		if strings.EqualFold(str, b.word) {
+			return b.value, nil
+		}
+		words = append(words, b.word)
+	}
+
+	return false, fmt.Errorf("invalid boolean %q, use one of %s", str, strings.Join(words, ", "))
+}
+
+// ParseInt parses a signed integer value which fits in bitSize bits, 0 meaning the size of an int.
+//
+// The value can have a 0x, 0o or 0b prefix and underscores between digits, like 0o644 or 1_000_000.
+func ParseInt(str string, bitSize int) (int64, error) {
+	return strconv.ParseInt(str, integerBase(str), bitSize)
+}
+
+// ParseUint is like ParseInt for unsigned integer values.
+func ParseUint(str string, bitSize int) (uint64, error) {
+	return strconv.ParseUint(str, integerBase(str), bitSize)
+}
+
+// ParseFloat parses a floating point value which fits in bitSize bits.
+func ParseFloat(str string, bitSize int) (float64, error) {
+	return strconv.ParseFloat(str, bitSize)
+}
+
+// integerBase returns the base to parse the integer str with.
+//
+// It's 0, letting strconv use the Go syntax, only if str has a base prefix or underscores: without them
+// a leading zero doesn't make the value octal, 0644 is still 644 like it always was.
+func integerBase(str string) int {
+	s := strings.TrimPrefix(strings.TrimPrefix(str, "-"), "+")
+	if len(s) > 2 && s[0] == '0' && strings.ContainsRune("xXoObB", rune(s[1])) {
+		return 0
+	}
+	if strings.Contains(s, "_") {
+		return 0
+	}
+	return 10
+}
+
+// ParseDuration parses a time.Duration value.
+func ParseDuration(str string) (time.Duration, error) {
+	return time.ParseDuration(str)
+}
+
+// Encodings of []byte values.
+const (
+	EncodingBase64    = "base64"    // standard base64, with padding
+	EncodingBase64URL = "base64url" // URL-safe base64, with or without padding
+	EncodingBase64Raw = "base64raw" // standard base64 without padding
+	EncodingHex       = "hex"
+	EncodingRaw       = "raw" // the bytes of the string as is
+)
+
+// ParseBytes parses a []byte value in the given encoding. An empty encoding means base64.
+func ParseBytes(str, encoding string) ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	switch encoding {
+	case "", EncodingBase64:
+		encoding = EncodingBase64
+		b, err = base64.StdEncoding.DecodeString(str)
+	case EncodingBase64URL:
+		b, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(str, "="))
+	case EncodingBase64Raw:
+		b, err = base64.RawStdEncoding.DecodeString(str)
+	case EncodingHex:
+		b, err = hex.DecodeString(str)
+	case EncodingRaw:
+		return []byte(str), nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q, use one of %s, %s, %s, %s or %s",
+			encoding, EncodingBase64, EncodingBase64URL, EncodingBase64Raw, EncodingHex, EncodingRaw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value: %w", encoding, err)
+	}
+
+	return b, nil
+}
+
+// ParseByteArray is like ParseBytes for a [size]byte value: the decoded value must have exactly size bytes.
+func ParseByteArray(str, encoding string, size int) ([]byte, error) {
+	b, err := ParseBytes(str, encoding)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("decoded value has %d bytes, expected %d", len(b), size)
+	}
+
+	return b, nil
+}
+
+// ParseEnum returns the integer value of str, which must be one of values.
+//
+// Each value is a name optionally followed by a colon and its integer value, like "tls:1". The integer value of
+// a name without one is its index in values, like for constants declared with iota. For a string type only the names matter.
+func ParseEnum(str string, values []string) (int64, error) {
+	names := make([]string, len(values))
+	for i, value := range values {
+		name, val, ok := strings.Cut(value, ":")
+		names[i] = name
+		if name != str {
+			continue
+		}
+
+		if !ok {
+			return int64(i), nil
+		}
+		n, err := ParseInt(val, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid integer value %q of %q", val, name)
+		}
+		return n, nil
+	}
+
+	return 0, fmt.Errorf("invalid value %q, use one of %s", str, strings.Join(names, ", "))
+}
@@ -0,0 +1,32 @@
+package envconfig
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+// JSONError is the error returned when a JSON value can't be decoded. Field is the field chain without the prefix,
+// like Database.URL, and Offset the offset in the value where the error occurred, or -1 if it's unknown.
+type JSONError = values.JSONError
+
+// readJSON decodes the JSON value of the field.
+func readJSON(value reflect.Value, ctx *context) (bool, error) {
+	str, err := readValue(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if len(str) == 0 && ctx.optional {
+		return false, nil
+	}
+
+	v := reflect.New(value.Type())
+	if err := json.Unmarshal([]byte(str), v.Interface()); err != nil {
+		return false, values.WrapJSONError(ctx.field(), ctx.keys, err)
+	}
+	value.Set(v.Elem())
+
+	return true, nil
+}
@@ -0,0 +1,95 @@
+package envconfig_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type route struct {
+	Path     string   `json:"path"`
+	Backends []string `json:"backends"`
+}
+
+func TestJSONTag(t *testing.T) {
+	var conf struct {
+		Routes  []route `envconfig:"json"`
+		Default *route  `envconfig:"json,optional"`
+		Limits  struct {
+			Max int `json:"max"`
+		} `envconfig:"json,default={\"max\":10}"`
+	}
+
+	src := envconfig.MapSource{
+		"ROUTES": `[{"path":"/api","backends":["a","b"]},{"path":"/"}]`,
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, []route{{Path: "/api", Backends: []string{"a", "b"}}, {Path: "/"}}, conf.Routes)
+	require.Nil(t, conf.Default)
+	require.Equal(t, 10, conf.Limits.Max)
+
+	src["DEFAULT"] = `{"path":"/default"}`
+	src["LIMITS"] = `{"max":20}`
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, &route{Path: "/default"}, conf.Default)
+	require.Equal(t, 20, conf.Limits.Max)
+}
+
+func TestDecodeJSON(t *testing.T) {
+	type config struct {
+		Routing map[string][]string
+		Matrix  [][]int
+		Name    string
+		Nested  struct {
+			Weights map[string]float64 `envconfig:"optional"`
+		}
+	}
+
+	src := envconfig.MapSource{
+		"ROUTING": `{"eu":["a","b"],"us":["c"]}`,
+		"MATRIX":  `[[1,2],[3]]`,
+		"NAME":    "foobar",
+	}
+
+	conf, err := envconfig.Load[config](envconfig.WithSource(src), envconfig.DecodeJSON())
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"eu": {"a", "b"}, "us": {"c"}}, conf.Routing)
+	require.Equal(t, [][]int{{1, 2}, {3}}, conf.Matrix)
+	require.Equal(t, "foobar", conf.Name)
+	require.Nil(t, conf.Nested.Weights)
+
+	_, err = envconfig.Load[config](envconfig.WithSource(src))
+	require.EqualError(t, err, "envconfig: kind map not supported")
+}
+
+func TestJSONErrors(t *testing.T) {
+	type config struct {
+		Routes []route `envconfig:"json"`
+	}
+
+	_, err := envconfig.Load[config](
+		envconfig.WithPrefix("APP"),
+		envconfig.WithSource(envconfig.MapSource{"APP_ROUTES": `[{"path":"/api",}]`}),
+	)
+
+	var jsonErr *envconfig.JSONError
+	require.True(t, errors.As(err, &jsonErr))
+	require.Equal(t, "Routes", jsonErr.Field)
+	require.Equal(t, int64(17), jsonErr.Offset)
+	require.EqualError(t, err, "envconfig: unable to decode JSON value of field Routes for possible keys [APP_ROUTES app_routes] at offset 17. err=invalid character '}' looking for beginning of object key string")
+
+	_, err = envconfig.Load[config](
+		envconfig.WithSource(envconfig.MapSource{"ROUTES": `[{"path":1}]`}),
+	)
+	var typeErr *json.UnmarshalTypeError
+	require.True(t, errors.As(err, &typeErr))
+	require.True(t, errors.As(err, &jsonErr))
+	require.Equal(t, "Routes", jsonErr.Field)
+	require.Equal(t, int64(10), jsonErr.Offset)
+}
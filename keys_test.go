@@ -13,9 +13,9 @@ func TestMakeAllPossibleKeys(t *testing.T) {
 	})
 
 	require.Equal(t, 4, len(keys))
-	require.Equal(t, "CASSANDRASSLCERT", keys[0])
-	require.Equal(t, "CASSANDRA_SSL_CERT", keys[1])
-	require.Equal(t, "cassandra_ssl_cert", keys[2])
+	require.Equal(t, "CASSANDRA_SSL_CERT", keys[0])
+	require.Equal(t, "cassandra_ssl_cert", keys[1])
+	require.Equal(t, "CASSANDRASSLCERT", keys[2])
 	require.Equal(t, "cassandrasslcert", keys[3])
 
 	fieldName = "CassandraSSLCert"
@@ -24,9 +24,9 @@ func TestMakeAllPossibleKeys(t *testing.T) {
 	})
 
 	require.Equal(t, 4, len(keys))
-	require.Equal(t, "CASSANDRASSLCERT", keys[0])
-	require.Equal(t, "CASSANDRA_SSL_CERT", keys[1])
-	require.Equal(t, "cassandra_ssl_cert", keys[2])
+	require.Equal(t, "CASSANDRA_SSL_CERT", keys[0])
+	require.Equal(t, "cassandra_ssl_cert", keys[1])
+	require.Equal(t, "CASSANDRASSLCERT", keys[2])
 	require.Equal(t, "cassandrasslcert", keys[3])
 
 	fieldName = "Cassandra.SslCert"
@@ -35,9 +35,9 @@ func TestMakeAllPossibleKeys(t *testing.T) {
 	})
 
 	require.Equal(t, 4, len(keys))
-	require.Equal(t, "CASSANDRA_SSLCERT", keys[0])
-	require.Equal(t, "CASSANDRA_SSL_CERT", keys[1])
-	require.Equal(t, "cassandra_ssl_cert", keys[2])
+	require.Equal(t, "CASSANDRA_SSL_CERT", keys[0])
+	require.Equal(t, "cassandra_ssl_cert", keys[1])
+	require.Equal(t, "CASSANDRA_SSLCERT", keys[2])
 	require.Equal(t, "cassandra_sslcert", keys[3])
 
 	fieldName = "Cassandra.SSLCert"
@@ -46,9 +46,9 @@ func TestMakeAllPossibleKeys(t *testing.T) {
 	})
 
 	require.Equal(t, 4, len(keys))
-	require.Equal(t, "CASSANDRA_SSLCERT", keys[0])
-	require.Equal(t, "CASSANDRA_SSL_CERT", keys[1])
-	require.Equal(t, "cassandra_ssl_cert", keys[2])
+	require.Equal(t, "CASSANDRA_SSL_CERT", keys[0])
+	require.Equal(t, "cassandra_ssl_cert", keys[1])
+	require.Equal(t, "CASSANDRA_SSLCERT", keys[2])
 	require.Equal(t, "cassandra_sslcert", keys[3])
 
 	fieldName = "Name"
@@ -60,3 +60,99 @@ func TestMakeAllPossibleKeys(t *testing.T) {
 	require.Equal(t, "NAME", keys[0])
 	require.Equal(t, "name", keys[1])
 }
+
+func TestMakeAllPossibleKeysCustomNames(t *testing.T) {
+	keys := makeAllPossibleKeys(&context{
+		name:        "Database.URL",
+		customNames: []string{"DATABASE_URL", "MYAPP_DB_URL"},
+	})
+	require.Equal(t, []string{"DATABASE_URL", "MYAPP_DB_URL"}, keys)
+
+	keys = makeAllPossibleKeys(&context{
+		name:        "Database.URL",
+		customNames: []string{"MYAPP_DB_URL", "auto"},
+	})
+	require.Equal(t, []string{"MYAPP_DB_URL", "DATABASE_URL", "database_url"}, keys)
+
+	keys = makeAllPossibleKeys(&context{
+		name:        "Database.URL",
+		customNames: []string{"auto", "DATABASE_URL", ""},
+	})
+	require.Equal(t, []string{"DATABASE_URL", "database_url"}, keys)
+}
+
+func TestWordSplitter(t *testing.T) {
+	testCases := []struct {
+		name  string
+		words []string
+	}{
+		{"Name", []string{"Name"}},
+		{"name", []string{"name"}},
+		{"SSLCert", []string{"SSL", "Cert"}},
+		{"CassandraSslCert", []string{"Cassandra", "Ssl", "Cert"}},
+		{"HTTPSProxyURL", []string{"HTTPS", "Proxy", "URL"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"S3Bucket", []string{"S3", "Bucket"}},
+		{"Ec2URL", []string{"Ec2", "URL"}},
+		{"Http2Enabled", []string{"Http2", "Enabled"}},
+		{"OAuth2Token", []string{"OAuth2", "Token"}},
+		{"OAuthToken", []string{"OAuth", "Token"}},
+		{"UserIDs", []string{"User", "IDs"}},
+		{"IPv4Addr", []string{"IPv4", "Addr"}},
+		{"IPv6Prefix", []string{"IPv6", "Prefix"}},
+		{"ABc", []string{"A", "Bc"}},
+		{"TTLSeconds", []string{"TTL", "Seconds"}},
+		{"UTF8Name", []string{"UTF8", "Name"}},
+		{"Max_Conns", []string{"Max", "Conns"}},
+		{"A", []string{"A"}},
+		{"", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.words, DefaultWordSplitter.Split(tc.name))
+		})
+	}
+}
+
+func TestWordSplitterCustomInitialisms(t *testing.T) {
+	words := NewWordSplitter(append(CommonInitialisms, "K8s")...)
+
+	require.Equal(t, []string{"K8s", "Cluster"}, words.Split("K8sCluster"))
+	require.Equal(t, []string{"K8", "s", "Cluster"}, DefaultWordSplitter.Split("K8sCluster"))
+	require.Equal(t, []string{"O", "Auth2", "Token"}, NewWordSplitter().Split("OAuth2Token"))
+	require.Equal(t, []string{"HTTPS", "Proxy", "URL"}, words.Split("HTTPSProxyURL"))
+	require.Equal(t, []string{"Oauth"}, words.Split("Oauth"))
+}
+
+func TestMakeAllPossibleKeysWordBoundaries(t *testing.T) {
+	testCases := []struct {
+		name string
+		keys []string
+	}{
+		{"S3Bucket", []string{"S3_BUCKET", "s3_bucket", "S3BUCKET", "s3bucket"}},
+		{"Ec2URL", []string{"EC2_URL", "ec2_url", "EC2URL", "ec2url"}},
+		{"Http2Enabled", []string{"HTTP2_ENABLED", "http2_enabled", "HTTP2ENABLED", "http2enabled"}},
+		{"HTTPSProxyURL", []string{"HTTPS_PROXY_URL", "https_proxy_url", "HTTPSPROXYURL", "httpsproxyurl"}},
+		// the keys generated before the word splitter still work
+		{"UserIDs", []string{"USER_IDS", "user_ids", "USER_I_DS", "user_i_ds", "USERIDS", "userids"}},
+		{"Log.ABc", []string{"LOG_A_BC", "log_a_bc", "LOG_ABC", "log_abc"}},
+		{"Aws.S3Bucket", []string{"AWS_S3_BUCKET", "aws_s3_bucket", "AWS_S3BUCKET", "aws_s3bucket"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.keys, makeAllPossibleKeys(&context{name: tc.name}))
+		})
+	}
+}
+
+func TestFlexibleNamesWith(t *testing.T) {
+	mapper := FlexibleNamesWith(NewWordSplitter("OAuth"))
+
+	require.Equal(t, []string{
+		"APP_OAUTH2_TOKEN", "app_oauth2_token",
+		"APP_O_AUTH2_TOKEN", "app_o_auth2_token",
+		"APP_OAUTH2TOKEN", "app_oauth2token",
+	}, mapper.Keys("APP", []string{"OAuth2Token"}))
+}
@@ -0,0 +1,119 @@
+package envconfig
+
+import (
+	"reflect"
+)
+
+// Option customizes the behavior of Load and MustLoad.
+type Option func(*Options)
+
+// WithPrefix sets Options.Prefix.
+func WithPrefix(prefix string) Option {
+	return func(o *Options) { o.Prefix = prefix }
+}
+
+// WithSource sets Options.Source.
+func WithSource(src Source) Option {
+	return func(o *Options) { o.Source = src }
+}
+
+// AllOptional sets Options.AllOptional.
+func AllOptional() Option {
+	return func(o *Options) { o.AllOptional = true }
+}
+
+// LeaveNil sets Options.LeaveNil.
+func LeaveNil() Option {
+	return func(o *Options) { o.LeaveNil = true }
+}
+
+// AllowUnexported sets Options.AllowUnexported.
+func AllowUnexported() Option {
+	return func(o *Options) { o.AllowUnexported = true }
+}
+
+// DisallowKeyCollisions sets Options.DisallowKeyCollisions.
+func DisallowKeyCollisions() Option {
+	return func(o *Options) { o.DisallowKeyCollisions = true }
+}
+
+// DisallowUnknownKeys sets Options.DisallowUnknownKeys.
+func DisallowUnknownKeys() Option {
+	return func(o *Options) { o.DisallowUnknownKeys = true }
+}
+
+// DisallowAmbiguousKeys sets Options.DisallowAmbiguousKeys.
+func DisallowAmbiguousKeys() Option {
+	return func(o *Options) { o.DisallowAmbiguousKeys = true }
+}
+
+// DecodeJSON sets Options.DecodeJSON.
+func DecodeJSON() Option {
+	return func(o *Options) { o.DecodeJSON = true }
+}
+
+// ExtendedBools sets Options.ExtendedBools.
+func ExtendedBools() Option {
+	return func(o *Options) { o.ExtendedBools = true }
+}
+
+// WithParser adds parse to Options.Parsers for the values of type T.
+func WithParser[T any](parse func(string) (T, error)) Option {
+	return func(o *Options) {
+		// copy the map so that the one given to WithOptions isn't modified
+		parsers := make(map[reflect.Type]func(string) (any, error), len(o.Parsers)+1)
+		for t, p := range o.Parsers {
+			parsers[t] = p
+		}
+		parsers[reflect.TypeOf((*T)(nil)).Elem()] = func(s string) (any, error) {
+			return parse(s)
+		}
+		o.Parsers = parsers
+	}
+}
+
+// WithNameMapper sets Options.NameMapper.
+func WithNameMapper(m NameMapper) Option {
+	return func(o *Options) { o.NameMapper = m }
+}
+
+// WithOptions replaces all options with opts. Options given after it still apply.
+func WithOptions(opts Options) Option {
+	return func(o *Options) { *o = opts }
+}
+
+// Load creates a new T and populates it like InitWithOptions does.
+//
+// T must be a struct or a pointer to a struct, otherwise ErrInvalidValueKind is returned.
+func Load[T any](opts ...Option) (T, error) {
+	var conf T
+
+	typ := reflect.TypeOf(&conf).Elem()
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return conf, ErrInvalidValueKind
+	}
+
+	var options Options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := InitWithOptions(&conf, options); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return conf, nil
+}
+
+// MustLoad is like Load but panics if there's an error.
+func MustLoad[T any](opts ...Option) T {
+	conf, err := Load[T](opts...)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
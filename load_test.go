@@ -0,0 +1,60 @@
+package envconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type loadedConfig struct {
+	Name string
+	Port int `envconfig:"default=80"`
+}
+
+func TestLoad(t *testing.T) {
+	src := envconfig.MapSource{"FOO_NAME": "foobar"}
+
+	conf, err := envconfig.Load[loadedConfig](envconfig.WithPrefix("FOO"), envconfig.WithSource(src))
+	require.NoError(t, err)
+	require.Equal(t, "foobar", conf.Name)
+	require.Equal(t, 80, conf.Port)
+
+	ptr, err := envconfig.Load[*loadedConfig](envconfig.WithPrefix("FOO"), envconfig.WithSource(src))
+	require.NoError(t, err)
+	require.Equal(t, "foobar", ptr.Name)
+
+	_, err = envconfig.Load[loadedConfig](envconfig.WithSource(src))
+	require.Error(t, err)
+
+	conf, err = envconfig.Load[loadedConfig](envconfig.WithSource(src), envconfig.AllOptional())
+	require.NoError(t, err)
+	require.Equal(t, "", conf.Name)
+}
+
+func TestLoadLeaveNil(t *testing.T) {
+	conf, err := envconfig.Load[struct {
+		MySQL *struct {
+			Name string
+		}
+	}](envconfig.WithSource(envconfig.MapSource{}), envconfig.AllOptional(), envconfig.LeaveNil())
+	require.NoError(t, err)
+	require.Nil(t, conf.MySQL)
+}
+
+func TestLoadInvalidType(t *testing.T) {
+	_, err := envconfig.Load[[]string]()
+	require.Equal(t, envconfig.ErrInvalidValueKind, err)
+
+	_, err = envconfig.Load[*int]()
+	require.Equal(t, envconfig.ErrInvalidValueKind, err)
+}
+
+func TestMustLoad(t *testing.T) {
+	require.Panics(t, func() {
+		envconfig.MustLoad[loadedConfig](envconfig.WithSource(envconfig.MapSource{}))
+	})
+
+	conf := envconfig.MustLoad[loadedConfig](envconfig.WithSource(envconfig.MapSource{"NAME": "foobar"}))
+	require.Equal(t, "foobar", conf.Name)
+}
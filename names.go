@@ -0,0 +1,97 @@
+package envconfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// NameMapper generates the keys of a field from its field chain.
+//
+// prefix is Options.Prefix, empty if there's none, and path is the field chain without it: for example
+// "MYAPP" and []string{"Cassandra", "SSLCert"}. Keys returns the keys to look up, in order. The prefix should
+// be used as given, like SeparatorNames does.
+//
+// Plans are cached per NameMapper so a NameMapper should be comparable, like a struct with comparable fields.
+// A NameMapper which is not comparable still works but is not cached.
+type NameMapper interface {
+	Keys(prefix string, path []string) []string
+}
+
+// FlexibleNames is the default NameMapper. It generates the upper and lower case variants of the field chain,
+// with and without underscores between words, for example CASSANDRA_SSL_CERT, cassandra_ssl_cert,
+// CASSANDRA_SSLCERT and cassandra_sslcert. Unlike the other NameMappers it also generates the upper and lower
+// case variants of the prefix, like envconfig always did.
+var FlexibleNames NameMapper = flexibleNames{}
+
+// FlexibleNamesWith is like FlexibleNames but splits words with words, for example to know more initialisms:
+//
+//	envconfig.FlexibleNamesWith(envconfig.NewWordSplitter(append(envconfig.CommonInitialisms, "K8s")...))
+func FlexibleNamesWith(words *WordSplitter) NameMapper {
+	return flexibleNames{words: words}
+}
+
+// ScreamingSnakeNames generates a single upper case key with underscores between words, for example CASSANDRA_SSL_CERT.
+var ScreamingSnakeNames NameMapper = SeparatorNames{LevelSeparator: "_", WordSeparator: "_"}
+
+// KebabNames generates a single lower case key with dots between struct levels and dashes between words,
+// for example cassandra.ssl-cert. It's meant for sources which are not the environment, like configuration files.
+var KebabNames NameMapper = SeparatorNames{LevelSeparator: ".", WordSeparator: "-", Lower: true}
+
+// DoubleUnderscoreNames generates a single upper case key with two underscores between struct levels,
+// for example CASSANDRA__SSL_CERT, like ASP.NET does.
+var DoubleUnderscoreNames NameMapper = SeparatorNames{LevelSeparator: "__", WordSeparator: "_"}
+
+type flexibleNames struct {
+	words *WordSplitter
+}
+
+func (m flexibleNames) Keys(prefix string, path []string) []string {
+	words := m.words
+	if words == nil {
+		words = DefaultWordSplitter
+	}
+
+	return makeGeneratedKeys(combineName(prefix, strings.Join(path, ".")), words)
+}
+
+// SeparatorNames is a NameMapper which generates a single key.
+// Words are separated by WordSeparator and struct levels by LevelSeparator.
+// The key is in upper case, or in lower case if Lower is true, except for the prefix which is kept as given.
+//
+// Words splits the words, if nil DefaultWordSplitter is used.
+type SeparatorNames struct {
+	LevelSeparator string
+	WordSeparator  string
+	Lower          bool
+	Words          *WordSplitter
+}
+
+// Keys implements NameMapper.
+func (m SeparatorNames) Keys(prefix string, path []string) []string {
+	words := m.Words
+	if words == nil {
+		words = DefaultWordSplitter
+	}
+
+	levels := make([]string, 0, len(path))
+	for _, elem := range path {
+		levels = append(levels, strings.Join(words.Split(elem), m.WordSeparator))
+	}
+
+	key := strings.Join(levels, m.LevelSeparator)
+	if m.Lower {
+		key = strings.ToLower(key)
+	} else {
+		key = strings.ToUpper(key)
+	}
+	if prefix != "" {
+		key = prefix + m.LevelSeparator + key
+	}
+
+	return []string{key}
+}
+
+// isComparable returns true if m can be used as a map key.
+func isComparable(m NameMapper) bool {
+	return m == nil || reflect.TypeOf(m).Comparable()
+}
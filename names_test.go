@@ -0,0 +1,88 @@
+package envconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type namedConfig struct {
+	Cassandra struct {
+		SSLCert string
+		Hosts   []string
+	}
+	LogLevel string `envconfig:"names=LOG_LEVEL|auto"`
+}
+
+func TestNameMappers(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mapper envconfig.NameMapper
+		src    envconfig.MapSource
+	}{
+		{"flexible", envconfig.FlexibleNames, envconfig.MapSource{
+			"app_cassandra_sslcert": "cert", "APP_CASSANDRA_HOSTS": "a,b", "app_log_level": "debug",
+		}},
+		{"screaming snake", envconfig.ScreamingSnakeNames, envconfig.MapSource{
+			"APP_CASSANDRA_SSL_CERT": "cert", "APP_CASSANDRA_HOSTS": "a,b", "APP_LOG_LEVEL": "debug",
+		}},
+		{"kebab", envconfig.KebabNames, envconfig.MapSource{
+			"APP.cassandra.ssl-cert": "cert", "APP.cassandra.hosts": "a,b", "APP.log-level": "debug",
+		}},
+		{"double underscore", envconfig.DoubleUnderscoreNames, envconfig.MapSource{
+			"APP__CASSANDRA__SSL_CERT": "cert", "APP__CASSANDRA__HOSTS": "a,b", "APP__LOG_LEVEL": "debug",
+		}},
+		{"custom separators", envconfig.SeparatorNames{LevelSeparator: "/", WordSeparator: ""}, envconfig.MapSource{
+			"APP/CASSANDRA/SSLCERT": "cert", "APP/CASSANDRA/HOSTS": "a,b", "APP/LOGLEVEL": "debug",
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf, err := envconfig.Load[namedConfig](
+				envconfig.WithPrefix("APP"),
+				envconfig.WithSource(tc.src),
+				envconfig.WithNameMapper(tc.mapper),
+			)
+			require.NoError(t, err)
+			require.Equal(t, "cert", conf.Cassandra.SSLCert)
+			require.Equal(t, []string{"a", "b"}, conf.Cassandra.Hosts)
+			require.Equal(t, "debug", conf.LogLevel)
+		})
+	}
+}
+
+func TestStrictNameMapper(t *testing.T) {
+	var conf namedConfig
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source:     envconfig.MapSource{"CASSANDRA_SSLCERT": "cert", "cassandra_hosts": "a"},
+		NameMapper: envconfig.ScreamingSnakeNames,
+	})
+	require.EqualError(t, err, "envconfig: keys CASSANDRA_SSL_CERT not found")
+
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source:     envconfig.MapSource{"CASSANDRA_SSL_CERT": "cert", "CASSANDRA_HOSTS": "a", "LOG_LEVEL": "info"},
+		NameMapper: envconfig.ScreamingSnakeNames,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "info", conf.LogLevel)
+}
+
+func TestNameMapperPrefix(t *testing.T) {
+	type config struct {
+		LogLevel string
+	}
+
+	// the prefix is kept as given
+	require.Equal(t, []string{"MY_APP.log-level"}, envconfig.KebabNames.Keys("MY_APP", []string{"LogLevel"}))
+
+	conf, err := envconfig.Load[config](
+		envconfig.WithPrefix("MY_APP"),
+		envconfig.WithSource(envconfig.MapSource{"MY_APP__LOG_LEVEL": "debug"}),
+		envconfig.WithNameMapper(envconfig.DoubleUnderscoreNames),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "debug", conf.LogLevel)
+}
@@ -0,0 +1,91 @@
+package envconfig
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+var (
+	urlType          = reflect.TypeOf(url.URL{})
+	ipType           = reflect.TypeOf(net.IP{})
+	ipNetType        = reflect.TypeOf(net.IPNet{})
+	hardwareAddrType = reflect.TypeOf(net.HardwareAddr{})
+	addrType         = reflect.TypeOf(netip.Addr{})
+	prefixType       = reflect.TypeOf(netip.Prefix{})
+	addrPortType     = reflect.TypeOf(netip.AddrPort{})
+)
+
+// HostPort is a network address made of a host and a port, like localhost:6379, [::1]:80 or :http.
+// It's validated with net.SplitHostPort.
+type HostPort struct {
+	Host string
+	Port string
+}
+
+// Unmarshal implements Unmarshaler.
+func (h *HostPort) Unmarshal(s string) error {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return err
+	}
+	if port == "" {
+		return fmt.Errorf("address %s: missing port", s)
+	}
+
+	h.Host, h.Port = host, port
+
+	return nil
+}
+
+// String returns the address in the form host:port.
+func (h HostPort) String() string {
+	return net.JoinHostPort(h.Host, h.Port)
+}
+
+func parseURLValue(v reflect.Value, str string, ctx *context) error {
+	u, err := values.ParseURL(str, ctx.schemes...)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(*u))
+
+	return nil
+}
+
+func parseIPValue(v reflect.Value, str string) error {
+	ip, err := values.ParseIP(str)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(ip))
+
+	return nil
+}
+
+func parseIPNetValue(v reflect.Value, str string) error {
+	n, err := values.ParseIPNet(str)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(*n))
+
+	return nil
+}
+
+// withParseFunc returns a parser using a parse function of the standard library, like netip.ParseAddr.
+func withParseFunc[T any](parse func(string) (T, error)) parserFunc {
+	return func(v reflect.Value, str string, _ *context) error {
+		val, err := parse(str)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(val))
+
+		return nil
+	}
+}
@@ -0,0 +1,126 @@
+package envconfig_test
+
+import (
+	"net"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+func TestNetConfig(t *testing.T) {
+	var conf struct {
+		Endpoint  url.URL
+		Proxy     *url.URL `envconfig:"schemes=http|https"`
+		Mirrors   []*url.URL
+		IP        net.IP
+		DNS       []net.IP
+		Network   net.IPNet
+		Allowed   []*net.IPNet
+		MAC       net.HardwareAddr
+		Addr      netip.Addr
+		Prefix    netip.Prefix
+		Listen    netip.AddrPort
+		Peers     []netip.AddrPort
+		Redis     envconfig.HostPort
+		Upstreams []envconfig.HostPort
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"ENDPOINT":  "postgres://user@localhost:5432/app",
+			"PROXY":     "https://proxy.local:3128",
+			"MIRRORS":   "https://a.example,https://b.example",
+			"IP":        "10.0.0.1",
+			"DNS":       "1.1.1.1,2606:4700:4700::1111",
+			"NETWORK":   "10.1.2.3/8",
+			"ALLOWED":   "192.168.0.0/16,fd00::/8",
+			"MAC":       "00:00:5e:00:53:01",
+			"ADDR":      "::1",
+			"PREFIX":    "172.16.0.0/12",
+			"LISTEN":    "0.0.0.0:8080",
+			"PEERS":     "10.0.0.2:7946,[fe80::1]:7946",
+			"REDIS":     "localhost:6379",
+			"UPSTREAMS": "a.local:80,[::1]:http",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "postgres", conf.Endpoint.Scheme)
+	require.Equal(t, "localhost:5432", conf.Endpoint.Host)
+	require.Equal(t, "https://proxy.local:3128", conf.Proxy.String())
+	require.Len(t, conf.Mirrors, 2)
+	require.Equal(t, "b.example", conf.Mirrors[1].Host)
+	require.Equal(t, net.ParseIP("10.0.0.1"), conf.IP)
+	require.Equal(t, []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("2606:4700:4700::1111")}, conf.DNS)
+	require.Equal(t, "10.0.0.0/8", conf.Network.String())
+	require.Equal(t, "fd00::/8", conf.Allowed[1].String())
+	require.Equal(t, "00:00:5e:00:53:01", conf.MAC.String())
+	require.Equal(t, netip.IPv6Loopback(), conf.Addr)
+	require.Equal(t, netip.MustParsePrefix("172.16.0.0/12"), conf.Prefix)
+	require.Equal(t, netip.MustParseAddrPort("0.0.0.0:8080"), conf.Listen)
+	require.Equal(t, []netip.AddrPort{
+		netip.MustParseAddrPort("10.0.0.2:7946"),
+		netip.MustParseAddrPort("[fe80::1]:7946"),
+	}, conf.Peers)
+	require.Equal(t, envconfig.HostPort{Host: "localhost", Port: "6379"}, conf.Redis)
+	require.Equal(t, []envconfig.HostPort{{Host: "a.local", Port: "80"}, {Host: "::1", Port: "http"}}, conf.Upstreams)
+	require.Equal(t, "[::1]:http", conf.Upstreams[1].String())
+}
+
+func TestNetConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		conf interface{}
+		src  envconfig.MapSource
+		err  string
+	}{
+		{
+			"scheme",
+			&struct {
+				Proxy *url.URL `envconfig:"schemes=http|https"`
+			}{},
+			envconfig.MapSource{"PROXY": "socks5://proxy.local"},
+			`envconfig: unable to parse value "socks5://proxy.local" for possible keys [PROXY proxy]. err=scheme "socks5" of URL "socks5://proxy.local" is not allowed, use one of http, https`,
+		},
+		{
+			"ip",
+			&struct{ IP net.IP }{},
+			envconfig.MapSource{"IP": "10.0.0.256"},
+			`envconfig: unable to parse value "10.0.0.256" for possible keys [IP ip]. err=invalid IP address "10.0.0.256"`,
+		},
+		{
+			"cidr",
+			&struct{ Network net.IPNet }{},
+			envconfig.MapSource{"NETWORK": "10.0.0.0"},
+			`envconfig: unable to parse value "10.0.0.0" for possible keys [NETWORK network]. err=invalid CIDR address: 10.0.0.0`,
+		},
+		{
+			"addr port",
+			&struct{ Listen netip.AddrPort }{},
+			envconfig.MapSource{"LISTEN": "localhost:80"},
+			`envconfig: unable to parse value "localhost:80" for possible keys [LISTEN listen]. err=ParseAddr("localhost"): unable to parse IP`,
+		},
+		{
+			"host port",
+			&struct{ Redis envconfig.HostPort }{},
+			envconfig.MapSource{"REDIS": "localhost"},
+			`envconfig: unable to parse value "localhost" for possible keys [REDIS redis]. err=address localhost: missing port in address`,
+		},
+		{
+			"host port without port",
+			&struct{ Redis envconfig.HostPort }{},
+			envconfig.MapSource{"REDIS": "localhost:"},
+			`envconfig: unable to parse value "localhost:" for possible keys [REDIS redis]. err=address localhost:: missing port`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := envconfig.InitWithOptions(tc.conf, envconfig.Options{Source: tc.src})
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var registeredParsers sync.Map // map[reflect.Type]parserFunc
+
+// RegisterParser registers a parse function for the values of type T, typically a type you don't own which
+// implements neither Unmarshaler nor encoding.TextUnmarshaler:
+//
+//	envconfig.RegisterParser(decimal.NewFromString)
+//
+// The parse function is used everywhere a T is read: fields, pointers, slice elements and struct tokens in slices.
+// It has priority over the other ways to read a T, like Unmarshaler.
+//
+// RegisterParser is meant to be called before Init, in an init function for example.
+// Use Options.Parsers to use a parse function for a single call.
+func RegisterParser[T any](parse func(string) (T, error)) {
+	registeredParsers.Store(reflect.TypeOf((*T)(nil)).Elem(), withParseFunc(parse))
+
+	// the cached parsers and plans may depend on the previous parser
+	resetCaches()
+}
+
+func registeredParser(t reflect.Type) parserFunc {
+	if p, ok := registeredParsers.Load(t); ok {
+		return p.(parserFunc)
+	}
+	return nil
+}
+
+// parsers are the parse functions of Options.Parsers, by type.
+type parsers map[reflect.Type]parserFunc
+
+func newParsers(m map[reflect.Type]func(string) (any, error)) parsers {
+	if len(m) == 0 {
+		return nil
+	}
+
+	res := make(parsers, len(m))
+	for t, parse := range m {
+		res[t] = anyParser(t, parse)
+	}
+
+	return res
+}
+
+// anyParser returns a parser using parse, which must return a value assignable to t.
+func anyParser(t reflect.Type, parse func(string) (any, error)) parserFunc {
+	return func(v reflect.Value, str string, _ *context) error {
+		val, err := parse(str)
+		if err != nil {
+			return err
+		}
+
+		rv := reflect.ValueOf(val)
+		if !rv.IsValid() || !rv.Type().AssignableTo(v.Type()) {
+			return fmt.Errorf("the parser of %s returned a %T", t, val)
+		}
+		v.Set(rv)
+
+		return nil
+	}
+}
+
+var (
+	parserTypeIDsMu sync.Mutex
+	parserTypeIDs   = make(map[reflect.Type]int) // emptied by resetCaches with the plans using the IDs
+)
+
+// key returns a string identifying the types of p, for the plan cache: the plans only depend on which types have
+// a parser, not on the parse functions.
+func (p parsers) key() string {
+	if len(p) == 0 {
+		return ""
+	}
+
+	ids := make([]int, 0, len(p))
+
+	parserTypeIDsMu.Lock()
+	for t := range p {
+		id, ok := parserTypeIDs[t]
+		if !ok {
+			id = len(parserTypeIDs) + 1
+			parserTypeIDs[t] = id
+		}
+		ids = append(ids, id)
+	}
+	parserTypeIDsMu.Unlock()
+
+	sort.Ints(ids)
+
+	var buf strings.Builder
+	for _, id := range ids {
+		buf.WriteString(strconv.Itoa(id))
+		buf.WriteByte(',')
+	}
+	return buf.String()
+}
+
+// parserFor is like the parserFor function, with the parsers of p first.
+func (p parsers) parserFor(t reflect.Type) parserFunc {
+	if parse := p[t]; parse != nil {
+		return parse
+	}
+	return parserFor(t)
+}
+
+// isValueType is like the isValueType function, with the types of p.
+func (p parsers) isValueType(t reflect.Type) bool {
+	return p[t] != nil || isValueType(t)
+}
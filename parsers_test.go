@@ -0,0 +1,151 @@
+package envconfig_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+// decimal is like a decimal type of a third-party package: a struct with unexported fields and no Unmarshal method.
+type decimal struct {
+	units int64
+	cents int64
+}
+
+func parseDecimal(s string) (decimal, error) {
+	units, cents, _ := strings.Cut(s, ".")
+
+	if len(cents) > 2 {
+		return decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	u, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	c, err := strconv.ParseInt(cents+"00"[len(cents):], 10, 64)
+	if err != nil {
+		return decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	return decimal{units: u, cents: c}, nil
+}
+
+func init() {
+	envconfig.RegisterParser(parseDecimal)
+}
+
+func TestRegisterParser(t *testing.T) {
+	var conf struct {
+		Price    decimal
+		Discount *decimal
+		Prices   []decimal
+		Products []struct {
+			Name  string
+			Price decimal
+		}
+		Shipping struct {
+			Price decimal `envconfig:"default=4.9"`
+		}
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"PRICE":    "12.5",
+			"DISCOUNT": "0.99",
+			"PRICES":   "1,2.25",
+			"PRODUCTS": "{book,10.99},{pen,1.2}",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, decimal{12, 50}, conf.Price)
+	require.Equal(t, decimal{0, 99}, *conf.Discount)
+	require.Equal(t, []decimal{{1, 0}, {2, 25}}, conf.Prices)
+	require.Len(t, conf.Products, 2)
+	require.Equal(t, "book", conf.Products[0].Name)
+	require.Equal(t, decimal{10, 99}, conf.Products[0].Price)
+	require.Equal(t, decimal{1, 20}, conf.Products[1].Price)
+	require.Equal(t, decimal{4, 90}, conf.Shipping.Price)
+}
+
+func TestRegisterParserError(t *testing.T) {
+	var conf struct{ Price decimal }
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{"PRICE": "12.505"},
+	})
+	require.EqualError(t, err, `envconfig: unable to parse value "12.505" for possible keys [PRICE price]. err=invalid decimal "12.505"`)
+}
+
+// point has unexported fields and is only read with Options.Parsers.
+type point struct {
+	x, y int
+}
+
+func parsePoint(s string) (point, error) {
+	var p point
+	if _, err := fmt.Sscanf(s, "%d:%d", &p.x, &p.y); err != nil {
+		return point{}, errors.New("invalid point")
+	}
+	return p, nil
+}
+
+func TestOptionsParsers(t *testing.T) {
+	type config struct {
+		Origin point
+		Path   []*point
+	}
+
+	src := envconfig.MapSource{
+		"ORIGIN": "1:2",
+		"PATH":   "3:4,5:6",
+	}
+
+	conf, err := envconfig.Load[config](envconfig.WithSource(src), envconfig.WithParser(parsePoint))
+	require.NoError(t, err)
+	require.Equal(t, point{1, 2}, conf.Origin)
+	require.Equal(t, []*point{{3, 4}, {5, 6}}, conf.Path)
+
+	// without the parser, point is read like a nested struct with unexported fields
+	_, err = envconfig.Load[config](envconfig.WithSource(src))
+	require.ErrorIs(t, err, envconfig.ErrUnexportedField)
+}
+
+func TestOptionsParsersOverrideRegistered(t *testing.T) {
+	var conf struct{ Price decimal }
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{"PRICE": "free"},
+		Parsers: map[reflect.Type]func(string) (any, error){
+			reflect.TypeOf(decimal{}): func(s string) (any, error) {
+				if s == "free" {
+					return decimal{}, nil
+				}
+				return parseDecimal(s)
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, decimal{}, conf.Price)
+}
+
+func TestOptionsParsersWrongType(t *testing.T) {
+	var conf struct{ Origin point }
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{"ORIGIN": "1:2"},
+		Parsers: map[reflect.Type]func(string) (any, error){
+			reflect.TypeOf(point{}): func(s string) (any, error) {
+				return s, nil
+			},
+		},
+	})
+	require.EqualError(t, err, `envconfig: unable to parse value "1:2" for possible keys [ORIGIN origin]. err=the parser of envconfig_test.point returned a string`)
+}
@@ -0,0 +1,292 @@
+package envconfig
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/vrischmann/envconfig/internal/tags"
+)
+
+// structPlan is the compiled form of a struct type: everything which only depends on the type
+// and the key prefix is computed once and reused by every Init call.
+type structPlan struct {
+	prefix string
+	fields []fieldPlan
+	// err is the error of the first invalid tag of the struct or of its nested structs.
+	err error
+
+	collisionsOnce    sync.Once
+	collisionsErr     error
+	collisionsLogOnce sync.Once
+
+	knownKeysOnce sync.Once
+	knownKeysSet  map[string]struct{}
+}
+
+type fieldPlan struct {
+	index      int
+	name       string // name of the field in the struct
+	path       string // field chain without the prefix, for example Bar.Baz
+	fullName   string // field chain including the prefix, for example Foo.Bar.Baz
+	tag        tags.Tag
+	unexported bool
+
+	// sub is the plan of the struct if the field is a struct (or a pointer to a struct) read recursively.
+	sub *structPlan
+	// keys are the keys to look up if the field is read from a single value.
+	keys []string
+	// indexed is true if the field is a slice read from indexed keys.
+	indexed bool
+	// typ is the type of the field after dereferencing pointers.
+	typ reflect.Type
+	// unsupported is true if the type of the field can't be read without decoding JSON.
+	unsupported bool
+	// iface is true if the field is an interface with implementations registered with RegisterType.
+	// Its keys are the keys of its type.
+	iface bool
+}
+
+type planKey struct {
+	typ     reflect.Type
+	prefix  string
+	parent  string
+	mapper  NameMapper
+	parsers string // see parsers.key
+}
+
+// cachedPlan is a plan of planCache and the generation it was built in.
+type cachedPlan struct {
+	plan *structPlan
+	gen  uint64
+}
+
+var (
+	planCache sync.Map // map[planKey]cachedPlan
+
+	// cacheGeneration is incremented by every registration. The cached plans and parsers store the generation they
+	// were built in, so that the ones built concurrently with a registration are replaced instead of being used.
+	cacheGeneration atomic.Uint64
+)
+
+// resetCaches empties the caches of plans and parsers, after a registration which changes them.
+func resetCaches() {
+	cacheGeneration.Add(1)
+
+	parserTypeIDsMu.Lock()
+	clear(parserTypeIDs)
+	parserTypeIDsMu.Unlock()
+
+	parserCache.Range(func(key, _ any) bool {
+		parserCache.Delete(key)
+		return true
+	})
+	planCache.Range(func(key, _ any) bool {
+		planCache.Delete(key)
+		return true
+	})
+}
+
+// getStructPlan returns the plan for the struct type typ with the keys prefixed by prefix and generated by mapper.
+// parent is the field chain of the struct without the prefix, empty for the configuration struct itself.
+// mapper can be nil, in which case FlexibleNames is used. parsers are the parsers of Options.Parsers, if any.
+func getStructPlan(typ reflect.Type, prefix, parent string, mapper NameMapper, parsers parsers) *structPlan {
+	if !isComparable(mapper) {
+		return newStructPlan(typ, prefix, parent, mapper, parsers)
+	}
+
+	// the generation is read first: the key of the parsers may change with a registration
+	gen := cacheGeneration.Load()
+	key := planKey{
+		typ:     typ,
+		prefix:  prefix,
+		parent:  parent,
+		mapper:  mapper,
+		parsers: parsers.key(),
+	}
+
+	if cached, ok := planCache.Load(key); ok && cached.(cachedPlan).gen == gen {
+		return cached.(cachedPlan).plan
+	}
+
+	plan := newStructPlan(typ, prefix, parent, mapper, parsers)
+	planCache.Store(key, cachedPlan{plan: plan, gen: gen})
+
+	return plan
+}
+
+func newStructPlan(typ reflect.Type, prefix, parent string, mapper NameMapper, parsers parsers) *structPlan {
+	plan := &structPlan{
+		prefix: prefix,
+		fields: make([]fieldPlan, typ.NumField()),
+	}
+
+	for i := range plan.fields {
+		fieldInfo := typ.Field(i)
+
+		field := &plan.fields[i]
+		field.index = i
+		field.name = fieldInfo.Name
+		field.path = combineName(parent, fieldInfo.Name)
+		field.fullName = combineName(prefix, field.path)
+		tag, err := tags.Parse(fieldInfo.Tag.Get("envconfig"))
+		if err != nil && plan.err == nil {
+			plan.err = fmt.Errorf("envconfig: %v in the envconfig tag of field %s", err, field.path)
+		}
+		field.tag = tag
+		field.unexported = fieldInfo.PkgPath != ""
+
+		if field.tag.Skip || field.unexported {
+			continue
+		}
+
+		// This must match what readStruct does when dereferencing pointers.
+		fieldType := fieldInfo.Type
+		t := fieldType
+		for t.Kind() == reflect.Ptr && !parsers.isValueType(t) {
+			t = t.Elem()
+		}
+
+		field.typ = t
+		field.unsupported = !parsers.isSupportedType(fieldType) && !parsers.isSupportedCollection(t, field.tag.Sep)
+		field.indexed = field.tag.Indexed && t.Kind() == reflect.Slice && !parsers.isValueType(t)
+		field.iface = hasImplementations(fieldType) && !field.tag.JSON
+
+		switch {
+		case t.Kind() == reflect.Struct && !parsers.isValueType(t) && !field.tag.JSON:
+			field.sub = newStructPlan(t, prefix, field.path, mapper, parsers)
+			if plan.err == nil {
+				plan.err = field.sub.err
+			}
+		case field.iface:
+			field.keys = makeAllPossibleKeys(&context{
+				name:        combineName(field.fullName, typeKeyName),
+				prefix:      prefix,
+				customNames: field.tag.CustomNames(),
+				mapper:      mapper,
+			})
+		default:
+			field.keys = makeAllPossibleKeys(&context{
+				name:        field.fullName,
+				prefix:      prefix,
+				customNames: field.tag.CustomNames(),
+				mapper:      mapper,
+			})
+		}
+	}
+
+	return plan
+}
+
+// isSupportedType returns true if envconfig can read a field of type t without decoding JSON.
+func (p parsers) isSupportedType(t reflect.Type) bool {
+	switch {
+	case p.isValueType(t), hasImplementations(t):
+		return true
+	case t.Kind() == reflect.Ptr:
+		return p.isSupportedType(t.Elem())
+	case t.Kind() == reflect.Struct:
+		return true
+	case t.Kind() == reflect.Slice:
+		return t == byteSliceType || p.isSupportedValueType(t.Elem())
+	default:
+		return p.isSupportedValueType(t)
+	}
+}
+
+// isSupportedValueType returns true if parseValue can parse a value of type t.
+func (p parsers) isSupportedValueType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr && p.parserFor(t) == nil {
+		t = t.Elem()
+	}
+	return p.parserFor(t) != nil
+}
+
+// parserFunc parses str and sets the result in v.
+type parserFunc func(v reflect.Value, str string, ctx *context) error
+
+// cachedParser is a parser of parserCache and the generation it was built in.
+type cachedParser struct {
+	parse parserFunc
+	gen   uint64
+}
+
+var parserCache sync.Map // map[reflect.Type]cachedParser
+
+// parserFor returns the parser for values of type t. It returns nil for pointers, which are handled
+// by parsing their element, except *time.Location, and for unsupported types.
+func parserFor(t reflect.Type) parserFunc {
+	gen := cacheGeneration.Load()
+
+	if cached, ok := parserCache.Load(t); ok && cached.(cachedParser).gen == gen {
+		return cached.(cachedParser).parse
+	}
+
+	p := newParser(t)
+	parserCache.Store(t, cachedParser{parse: p, gen: gen})
+
+	return p
+}
+
+func newParser(t reflect.Type) parserFunc {
+	kind := t.Kind()
+	switch {
+	case registeredParser(t) != nil:
+		return registeredParser(t)
+	case isUnmarshaler(t):
+		// Special case for Unmarshaler
+		return withoutContext(parseWithUnmarshaler)
+	case valueParsers[t] != nil:
+		return valueParsers[t]
+	case isDurationField(t):
+		// Special case for time.Duration
+		return withoutContext(parseDuration)
+	case isEnum(t) && isEnumKind(t):
+		return parseEnumValue
+	case kind == reflect.Bool:
+		return parseBoolValue
+	case kind == reflect.Int, kind == reflect.Int8, kind == reflect.Int16, kind == reflect.Int32, kind == reflect.Int64:
+		return parseIntValue
+	case kind == reflect.Uint, kind == reflect.Uint8, kind == reflect.Uint16, kind == reflect.Uint32, kind == reflect.Uint64:
+		return parseUintValue
+	case kind == reflect.Float32, kind == reflect.Float64:
+		return withoutContext(parseFloatValue)
+	case kind == reflect.String:
+		return withoutContext(parseStringValue)
+	case kind == reflect.Struct:
+		return parseStruct
+	case kind == reflect.Array && t.Elem().Kind() == reflect.Uint8:
+		return parseByteArrayValue
+	default:
+		return nil
+	}
+}
+
+// valueParsers are the parsers of the struct, pointer and slice types of the standard library which are read
+// from a single value.
+var valueParsers = map[reflect.Type]parserFunc{
+	timeType:         parseTimeValue,
+	locationType:     withoutContext(parseLocationValue),
+	urlType:          parseURLValue,
+	ipType:           withoutContext(parseIPValue),
+	ipNetType:        withoutContext(parseIPNetValue),
+	hardwareAddrType: withParseFunc(net.ParseMAC),
+	addrType:         withParseFunc(netip.ParseAddr),
+	prefixType:       withParseFunc(netip.ParsePrefix),
+	addrPortType:     withParseFunc(netip.ParseAddrPort),
+}
+
+// isValueType returns true if a value of type t is read from a single value even though it's a struct, a pointer
+// or a slice: the Unmarshaler types, the types of valueParsers and the types registered with RegisterParser.
+func isValueType(t reflect.Type) bool {
+	return isUnmarshaler(t) || valueParsers[t] != nil || registeredParser(t) != nil
+}
+
+func withoutContext(fn func(v reflect.Value, str string) error) parserFunc {
+	return func(v reflect.Value, str string, _ *context) error {
+		return fn(v, str)
+	}
+}
@@ -0,0 +1,125 @@
+package envconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructPlan(t *testing.T) {
+	type conf struct {
+		Name      string `envconfig:"myName,optional"`
+		Cassandra *struct {
+			SSLCert string
+		}
+		Skipped  string `envconfig:"-"`
+		internal string
+	}
+
+	typ := reflect.TypeOf(conf{})
+
+	plan := getStructPlan(typ, "APP", "", nil, nil)
+	require.True(t, plan == getStructPlan(typ, "APP", "", nil, nil), "plan should be cached")
+	require.False(t, plan == getStructPlan(typ, "OTHER", "", nil, nil), "plan depends on the prefix")
+	require.False(t, plan == getStructPlan(typ, "APP", "", ScreamingSnakeNames, nil), "plan depends on the name mapper")
+
+	require.Equal(t, 4, len(plan.fields))
+
+	require.Equal(t, "APP.Name", plan.fields[0].fullName)
+	require.Equal(t, []string{"myName"}, plan.fields[0].keys)
+	require.True(t, plan.fields[0].tag.Optional)
+
+	sub := plan.fields[1].sub
+	require.NotNil(t, sub)
+	require.Nil(t, plan.fields[1].keys)
+	require.Equal(t, "APP.Cassandra.SSLCert", sub.fields[0].fullName)
+	require.Equal(t, []string{"APP_CASSANDRA_SSL_CERT", "app_cassandra_ssl_cert", "APP_CASSANDRA_SSLCERT", "app_cassandra_sslcert"}, sub.fields[0].keys)
+
+	require.True(t, plan.fields[2].tag.Skip)
+	require.True(t, plan.fields[3].unexported)
+}
+
+type funcNames func(prefix string, path []string) []string
+
+func (f funcNames) Keys(prefix string, path []string) []string { return f(prefix, path) }
+
+func TestStructPlanNameMapper(t *testing.T) {
+	type conf struct {
+		Cassandra struct {
+			SSLCert string
+		}
+	}
+
+	typ := reflect.TypeOf(conf{})
+
+	plan := getStructPlan(typ, "APP", "", DoubleUnderscoreNames, nil)
+	require.True(t, plan == getStructPlan(typ, "APP", "", DoubleUnderscoreNames, nil), "plan should be cached")
+	require.Equal(t, []string{"APP__CASSANDRA__SSL_CERT"}, plan.fields[0].sub.fields[0].keys)
+
+	// not comparable so not cached
+	mapper := funcNames(func(prefix string, path []string) []string { return []string{"KEY"} })
+	plan = getStructPlan(typ, "APP", "", mapper, nil)
+	require.False(t, plan == getStructPlan(typ, "APP", "", mapper, nil), "plan should not be cached")
+	require.Equal(t, []string{"KEY"}, plan.fields[0].sub.fields[0].keys)
+}
+
+func TestStructPlanParsers(t *testing.T) {
+	type point struct{ X, Y int }
+	type conf struct {
+		Origin point
+	}
+
+	typ := reflect.TypeOf(conf{})
+	parse := func(string) (any, error) { return point{}, nil }
+
+	plan := getStructPlan(typ, "", "", nil, nil)
+	require.NotNil(t, plan.fields[0].sub)
+
+	withParser := getStructPlan(typ, "", "", nil, newParsers(map[reflect.Type]func(string) (any, error){reflect.TypeOf(point{}): parse}))
+	require.False(t, plan == withParser, "plan depends on the parser types")
+	require.Nil(t, withParser.fields[0].sub)
+
+	other := newParsers(map[reflect.Type]func(string) (any, error){reflect.TypeOf(point{}): parse})
+	require.True(t, withParser == getStructPlan(typ, "", "", nil, other), "plan should be cached for the same parser types")
+}
+
+func TestStructPlanResetCaches(t *testing.T) {
+	type conf struct {
+		Name string
+	}
+
+	typ := reflect.TypeOf(conf{})
+
+	// a plan built before a registration must not be used after it, even if it's stored after the caches are reset
+	key := planKey{typ: typ}
+	gen := cacheGeneration.Load()
+	resetCaches()
+	stale := newStructPlan(typ, "", "", nil, nil)
+	planCache.Store(key, cachedPlan{plan: stale, gen: gen})
+
+	plan := getStructPlan(typ, "", "", nil, nil)
+	require.False(t, plan == stale)
+	require.True(t, plan == getStructPlan(typ, "", "", nil, nil), "plan should be cached")
+
+	// the stale plan is replaced, not kept next to the new one
+	cached, ok := planCache.Load(key)
+	require.True(t, ok)
+	require.True(t, cached.(cachedPlan).plan == plan)
+
+	n := 0
+	planCache.Range(func(key, _ any) bool {
+		if key.(planKey).typ == typ {
+			n++
+		}
+		return true
+	})
+	require.Equal(t, 1, n)
+
+	// the parser type IDs are reset too
+	newParsers(map[reflect.Type]func(string) (any, error){typ: nil}).key()
+	resetCaches()
+	parserTypeIDsMu.Lock()
+	require.Empty(t, parserTypeIDs)
+	parserTypeIDsMu.Unlock()
+}
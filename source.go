@@ -0,0 +1,168 @@
+package envconfig
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source is the interface implemented by objects that can provide the values envconfig reads.
+//
+// Lookup returns the value for the key and whether it was present. An empty value is treated the same
+// as a missing key.
+type Source interface {
+	Lookup(key string) (string, bool)
+}
+
+// EnvSource is the source which reads from the process environment. This is the default source.
+var EnvSource Source = envSource{}
+
+type envSource struct{}
+
+func (envSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// LookupFunc is an adapter to use a lookup function as a Source.
+type LookupFunc func(key string) (string, bool)
+
+// Lookup implements Source.
+func (f LookupFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// MapSource is a source backed by a map. It's mostly useful for tests or to read configuration
+// from somewhere else than the environment.
+type MapSource map[string]string
+
+// Lookup implements Source.
+func (s MapSource) Lookup(key string) (string, bool) {
+	v, ok := s[key]
+	return v, ok
+}
+
+// MultiSource combines multiple sources. The first source having a non-empty value for a key wins.
+type MultiSource []Source
+
+// Lookup implements Source.
+func (s MultiSource) Lookup(key string) (string, bool) {
+	for _, src := range s {
+		if v, ok := src.Lookup(key); ok && v != "" {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// FileSource is a source which reads values from a file of KEY=VALUE lines, like a .env file.
+//
+// Empty lines and lines starting with # are ignored, an optional "export " prefix is stripped and
+// values can be surrounded by single or double quotes.
+//
+// The file is read when creating the source and every time Reload is called and the file changed.
+type FileSource struct {
+	path string
+
+	mu      sync.RWMutex
+	values  map[string]string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileSource creates a new FileSource reading the file at path.
+func NewFileSource(path string) (*FileSource, error) {
+	s := &FileSource{path: path}
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Lookup implements Source.
+func (s *FileSource) Lookup(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.values[key]
+	return v, ok
+}
+
+// Reload reads the file again if it changed since the last time it was read.
+// It returns true if the file was read.
+func (s *FileSource) Reload() (changed bool, err error) {
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	unchanged := s.values != nil && fi.ModTime().Equal(s.modTime) && fi.Size() == s.size
+	s.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return false, err
+	}
+
+	values, err := parseEnvFile(data)
+	if err != nil {
+		return false, fmt.Errorf("envconfig: unable to parse file %q. err=%v", s.path, err)
+	}
+
+	s.mu.Lock()
+	s.values = values
+	s.modTime = fi.ModTime()
+	s.size = fi.Size()
+	s.mu.Unlock()
+
+	return true, nil
+}
+
+func parseEnvFile(data []byte) (map[string]string, error) {
+	res := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		pos := strings.IndexRune(line, '=')
+		if pos <= 0 {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+
+		key := strings.TrimSpace(line[:pos])
+		value := strings.TrimSpace(line[pos+1:])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		res[key] = value
+	}
+
+	return res, scanner.Err()
+}
+
+// reloadableSources returns all the sources which can be reloaded, including the ones inside a MultiSource.
+func reloadableSources(src Source) (res []*FileSource) {
+	switch s := src.(type) {
+	case *FileSource:
+		res = append(res, s)
+	case MultiSource:
+		for _, v := range s {
+			res = append(res, reloadableSources(v)...)
+		}
+	}
+	return
+}
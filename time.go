@@ -0,0 +1,33 @@
+package envconfig
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/vrischmann/envconfig/internal/values"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	locationType = reflect.TypeOf((*time.Location)(nil))
+)
+
+func parseTimeValue(v reflect.Value, str string, ctx *context) error {
+	t, err := values.ParseTime(str, ctx.layout)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(t))
+
+	return nil
+}
+
+func parseLocationValue(v reflect.Value, str string) error {
+	loc, err := values.ParseLocation(str)
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(loc))
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package envconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+func TestTimeConfig(t *testing.T) {
+	var conf struct {
+		Cutover     time.Time
+		Window      *time.Time  `envconfig:"layout=2006-01-02 15:04"`
+		Created     time.Time   `envconfig:"layout=unix"`
+		Updated     time.Time   `envconfig:"layout=unixms"`
+		Expires     time.Time   `envconfig:"layout=RFC1123"`
+		Holidays    []time.Time `envconfig:"layout=DateOnly"`
+		Zone        *time.Location
+		Maintenance struct {
+			Start time.Time      `envconfig:"default=2024-01-01T02:00:00Z"`
+			Zone  *time.Location `envconfig:"default=UTC"`
+		}
+	}
+
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Source: envconfig.MapSource{
+			"CUTOVER":  "2024-03-01T12:30:00+01:00",
+			"WINDOW":   "2024-03-02 04:00",
+			"CREATED":  "1700000000",
+			"UPDATED":  "1700000000123",
+			"EXPIRES":  "Mon, 02 Jan 2006 15:04:05 UTC",
+			"HOLIDAYS": "2024-12-25,2025-01-01",
+			"ZONE":     "Europe/Paris",
+		},
+	})
+	require.NoError(t, err)
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	require.True(t, time.Date(2024, 3, 1, 11, 30, 0, 0, time.UTC).Equal(conf.Cutover))
+	require.Equal(t, time.Date(2024, 3, 2, 4, 0, 0, 0, time.UTC), *conf.Window)
+	require.Equal(t, time.Unix(1700000000, 0), conf.Created)
+	require.Equal(t, time.UnixMilli(1700000000123), conf.Updated)
+	require.Equal(t, 2006, conf.Expires.Year())
+	require.Equal(t, []time.Time{
+		time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, conf.Holidays)
+	require.Equal(t, paris, conf.Zone)
+	require.Equal(t, time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC), conf.Maintenance.Start)
+	require.Equal(t, time.UTC, conf.Maintenance.Zone)
+}
+
+func TestTimeConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		conf interface{}
+		src  envconfig.MapSource
+		err  string
+	}{
+		{
+			"rfc 3339",
+			&struct{ Cutover time.Time }{},
+			envconfig.MapSource{"CUTOVER": "2024-03-01"},
+			`envconfig: unable to parse value "2024-03-01" for possible keys [CUTOVER cutover]. err=parsing time "2024-03-01" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "T"`,
+		},
+		{
+			"unix",
+			&struct {
+				Created time.Time `envconfig:"layout=unix"`
+			}{},
+			envconfig.MapSource{"CREATED": "yesterday"},
+			`envconfig: unable to parse value "yesterday" for possible keys [CREATED created]. err=invalid unix time "yesterday"`,
+		},
+		{
+			"location",
+			&struct{ Zone *time.Location }{},
+			envconfig.MapSource{"ZONE": "Mars/Olympus"},
+			`envconfig: unable to parse value "Mars/Olympus" for possible keys [ZONE zone]. err=unknown time zone Mars/Olympus`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := envconfig.InitWithOptions(tc.conf, envconfig.Options{Source: tc.src})
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package envconfig
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/vrischmann/envconfig/internal/editdistance"
+)
+
+// KeyLister is implemented by sources which can list their keys. It is required by Options.DisallowUnknownKeys.
+type KeyLister interface {
+	Keys() []string
+}
+
+// Keys implements KeyLister.
+func (envSource) Keys() []string {
+	env := os.Environ()
+
+	res := make([]string, 0, len(env))
+	for _, v := range env {
+		if pos := strings.IndexRune(v, '='); pos > 0 {
+			res = append(res, v[:pos])
+		}
+	}
+
+	return res
+}
+
+// Keys implements KeyLister.
+func (s MapSource) Keys() []string {
+	res := make([]string, 0, len(s))
+	for k := range s {
+		res = append(res, k)
+	}
+	return res
+}
+
+// Keys implements KeyLister.
+func (s *FileSource) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]string, 0, len(s.values))
+	for k := range s.values {
+		res = append(res, k)
+	}
+	return res
+}
+
+// Keys implements KeyLister. Sources which are not a KeyLister are ignored.
+func (s MultiSource) Keys() []string {
+	var res []string
+	for _, src := range s {
+		if l, ok := src.(KeyLister); ok {
+			res = append(res, l.Keys()...)
+		}
+	}
+	return res
+}
+
+// UnknownKey is a key having the prefix which is not used by any field.
+type UnknownKey struct {
+	Key string
+	// Suggestion is the closest key used by a field, if there's one close enough.
+	Suggestion string
+}
+
+// UnknownKeysError is the error returned when Options.DisallowUnknownKeys is used and there are unknown keys.
+type UnknownKeysError struct {
+	Prefix string
+	Keys   []UnknownKey
+}
+
+func (e *UnknownKeysError) Error() string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, "envconfig: unknown keys with prefix %s: ", e.Prefix)
+	for i, k := range e.Keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(k.Key)
+		if k.Suggestion != "" {
+			fmt.Fprintf(&buf, " (did you mean %s?)", k.Suggestion)
+		}
+	}
+
+	return buf.String()
+}
+
+// checkUnknownKeys returns an *UnknownKeysError if src has keys starting with the prefix which no field uses.
+func checkUnknownKeys(plan *structPlan, prefix string, src Source, mapper NameMapper, parsers parsers) error {
+	if err := checkKeyLister(src); err != nil {
+		return err
+	}
+	lister := src.(KeyLister)
+
+	known := plan.knownKeys()
+	indexedKeys := make(map[string]struct{})
+	plan.walkDynamicKeys(src, mapper, parsers, func(key string) {
+		indexedKeys[key] = struct{}{}
+	})
+	prefixes := keyPrefixes(prefix, mapper)
+
+	var unknown []UnknownKey
+	seen := make(map[string]struct{})
+	for _, key := range lister.Keys() {
+		if !hasAnyPrefix(strings.ToLower(key), prefixes) {
+			continue
+		}
+		if _, ok := known[key]; ok {
+			continue
+		}
+		if _, ok := indexedKeys[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		unknown = append(unknown, UnknownKey{
+			Key:        key,
+			Suggestion: suggestKey(key, known),
+		})
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Key < unknown[j].Key })
+
+	return &UnknownKeysError{Prefix: prefix, Keys: unknown}
+}
+
+// keyPrefixes returns the beginnings of the keys generated by mapper for the fields under prefix, in lower case,
+// like myapp_ with FlexibleNames or myapp. with KebabNames.
+func keyPrefixes(prefix string, mapper NameMapper) []string {
+	if mapper == nil {
+		mapper = FlexibleNames
+	}
+
+	// map a field named X and keep what comes before it
+	const probe = "X"
+
+	var res []string
+	for _, key := range mapper.Keys(prefix, []string{probe}) {
+		if len(key) > len(probe) && strings.EqualFold(key[len(key)-len(probe):], probe) {
+			res = append(res, strings.ToLower(key[:len(key)-len(probe)]))
+		}
+	}
+
+	return res
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkKeyLister(src Source) error {
+	if multi, ok := src.(MultiSource); ok {
+		for _, s := range multi {
+			if err := checkKeyLister(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, ok := src.(KeyLister); !ok {
+		return fmt.Errorf("envconfig: source %T can't list its keys, it can't be used with DisallowUnknownKeys", src)
+	}
+
+	return nil
+}
+
+// knownKeys returns all the keys used by the fields of the plan. It is computed once per plan.
+func (p *structPlan) knownKeys() map[string]struct{} {
+	p.knownKeysOnce.Do(func() {
+		p.knownKeysSet = make(map[string]struct{})
+		p.walkFields("", func(_ string, field *fieldPlan) {
+			for _, key := range field.keys {
+				p.knownKeysSet[key] = struct{}{}
+			}
+			for _, key := range field.tag.Deprecated {
+				p.knownKeysSet[key] = struct{}{}
+			}
+		})
+	})
+
+	return p.knownKeysSet
+}
+
+// suggestKey returns the known key closest to key, if it's close enough.
+func suggestKey(key string, known map[string]struct{}) string {
+	var (
+		best     string
+		bestDist = len(key)/3 + 1
+	)
+
+	for k := range known {
+		d := editdistance.Levenshtein(key, k)
+		if d < bestDist || (d == bestDist && best != "" && k < best) {
+			best, bestDist = k, d
+		}
+	}
+
+	return best
+}
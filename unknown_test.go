@@ -0,0 +1,124 @@
+package envconfig_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type unknownKeysConfig struct {
+	Database struct {
+		URL string
+	}
+	Port int `envconfig:"optional"`
+}
+
+func TestDisallowUnknownKeys(t *testing.T) {
+	src := envconfig.MapSource{
+		"MYAPP_DATABASE_URL": "postgres://localhost",
+		"MYAPP_DATABSE_URL":  "postgres://typo",
+		"MYAPP_FOOBAR":       "foobar",
+		"OTHERAPP_PORT":      "80",
+	}
+
+	var conf unknownKeysConfig
+	err := envconfig.InitWithOptions(&conf, envconfig.Options{
+		Prefix:              "MYAPP",
+		Source:              src,
+		DisallowUnknownKeys: true,
+	})
+
+	var unknownErr *envconfig.UnknownKeysError
+	require.True(t, errors.As(err, &unknownErr))
+	require.Equal(t, []envconfig.UnknownKey{
+		{Key: "MYAPP_DATABSE_URL", Suggestion: "MYAPP_DATABASE_URL"},
+		{Key: "MYAPP_FOOBAR"},
+	}, unknownErr.Keys)
+	require.Equal(t, "envconfig: unknown keys with prefix MYAPP: MYAPP_DATABSE_URL (did you mean MYAPP_DATABASE_URL?), MYAPP_FOOBAR", err.Error())
+
+	// not enabled by default
+	err = envconfig.InitWithOptions(&conf, envconfig.Options{Prefix: "MYAPP", Source: src})
+	require.NoError(t, err)
+	require.Equal(t, "postgres://localhost", conf.Database.URL)
+
+	// all keys known
+	delete(src, "MYAPP_DATABSE_URL")
+	delete(src, "MYAPP_FOOBAR")
+	src["myapp_port"] = "80"
+	_, err = envconfig.Load[unknownKeysConfig](envconfig.WithPrefix("MYAPP"), envconfig.WithSource(src), envconfig.DisallowUnknownKeys())
+	require.NoError(t, err)
+}
+
+func TestDisallowUnknownKeysEnv(t *testing.T) {
+	os.Setenv("MYAPP_DATABASE_URL", "postgres://localhost")
+	os.Setenv("MYAPP_PROT", "80")
+	defer os.Unsetenv("MYAPP_DATABASE_URL")
+	defer os.Unsetenv("MYAPP_PROT")
+
+	_, err := envconfig.Load[unknownKeysConfig](envconfig.WithPrefix("MYAPP"), envconfig.DisallowUnknownKeys())
+	require.EqualError(t, err, "envconfig: unknown keys with prefix MYAPP: MYAPP_PROT (did you mean MYAPP_PORT?)")
+}
+
+func TestDisallowUnknownKeysNotListable(t *testing.T) {
+	src := envconfig.MultiSource{
+		envconfig.MapSource{},
+		envconfig.LookupFunc(func(string) (string, bool) { return "", false }),
+	}
+
+	_, err := envconfig.Load[unknownKeysConfig](envconfig.WithPrefix("MYAPP"), envconfig.WithSource(src), envconfig.DisallowUnknownKeys())
+	require.EqualError(t, err, "envconfig: source envconfig.LookupFunc can't list its keys, it can't be used with DisallowUnknownKeys")
+}
+
+func TestDisallowUnknownKeysNameMapper(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mapper envconfig.NameMapper
+		prefix string
+		src    envconfig.MapSource
+		err    string
+	}{
+		{
+			"flexible",
+			envconfig.FlexibleNames,
+			"app",
+			envconfig.MapSource{"APP_DATABASE_URL": "url", "app_databse_url": "typo", "APPLICATION_NAME": "other"},
+			"envconfig: unknown keys with prefix app: app_databse_url (did you mean app_database_url?)",
+		},
+		{
+			"screaming snake",
+			envconfig.ScreamingSnakeNames,
+			"APP",
+			envconfig.MapSource{"APP_DATABASE_URL": "url", "APP_DATABSE_URL": "typo", "APPLICATION_NAME": "other"},
+			"envconfig: unknown keys with prefix APP: APP_DATABSE_URL (did you mean APP_DATABASE_URL?)",
+		},
+		{
+			"double underscore",
+			envconfig.DoubleUnderscoreNames,
+			"APP",
+			envconfig.MapSource{"APP__DATABASE__URL": "url", "APP__DATABSE__URL": "typo", "APP_NAME": "other"},
+			"envconfig: unknown keys with prefix APP: APP__DATABSE__URL (did you mean APP__DATABASE__URL?)",
+		},
+		{
+			"kebab",
+			envconfig.KebabNames,
+			"app",
+			envconfig.MapSource{"app.database.url": "url", "app.databse.url": "typo", "application.name": "other"},
+			"envconfig: unknown keys with prefix app: app.databse.url (did you mean app.database.url?)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := envconfig.Load[unknownKeysConfig](
+				envconfig.WithPrefix(tc.prefix),
+				envconfig.WithSource(tc.src),
+				envconfig.WithNameMapper(tc.mapper),
+				envconfig.DisallowUnknownKeys(),
+			)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
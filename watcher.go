@@ -0,0 +1,214 @@
+package envconfig
+
+import (
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Watcher holds a configuration of type T and reloads it on demand, when the process receives a SIGHUP
+// or when a FileSource changes.
+//
+// A reload builds a fresh value with the same options as the initial load. If T (or *T) has a
+// Validate() error method it is called on the new value. The current configuration is only replaced
+// if both loading and validating succeed, otherwise the old configuration stays in place.
+//
+// T must be a struct type.
+type Watcher[T any] struct {
+	opts    Options
+	current atomic.Pointer[T]
+
+	mu          sync.Mutex // serializes reloads and protects subscribers
+	subscribers []func(old, new *T, changed []string)
+}
+
+// NewWatcher creates a new watcher and loads the initial configuration.
+func NewWatcher[T any](opts Options) (*Watcher[T], error) {
+	w := &Watcher[T]{opts: opts}
+
+	conf, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.current.Store(conf)
+
+	return w, nil
+}
+
+// Get returns the current configuration. The returned value must not be modified.
+func (w *Watcher[T]) Get() *T {
+	return w.current.Load()
+}
+
+// Subscribe registers fn to be called after each reload which changed the configuration.
+//
+// fn is called with the old and new configuration and the field paths which changed, for example "Log.Level".
+// It's called without any lock held, so it can call Subscribe or Reload.
+func (w *Watcher[T]) Subscribe(fn func(old, new *T, changed []string)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload re-reads all file sources and loads the configuration again.
+//
+// If the new configuration is different from the current one it is swapped in and the subscribers are notified.
+func (w *Watcher[T]) Reload() error {
+	for _, s := range reloadableSources(w.opts.Source) {
+		if _, err := s.Reload(); err != nil {
+			return err
+		}
+	}
+
+	return w.reload()
+}
+
+// Watch reloads the configuration every time the process receives a SIGHUP and every time
+// a FileSource changes. File sources are checked every interval; an interval of 0 disables that check.
+//
+// Watch blocks until done is closed. Reload errors are passed to errFn if it's not nil.
+func (w *Watcher[T]) Watch(done <-chan struct{}, interval time.Duration, errFn func(error)) {
+	if errFn == nil {
+		errFn = func(error) {}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	var tickCh <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-sigCh:
+			if err := w.Reload(); err != nil {
+				errFn(err)
+			}
+
+		case <-tickCh:
+			changed, err := w.reloadSources()
+			if err != nil {
+				errFn(err)
+				continue
+			}
+			if !changed {
+				continue
+			}
+			if err := w.reload(); err != nil {
+				errFn(err)
+			}
+		}
+	}
+}
+
+func (w *Watcher[T]) reloadSources() (changed bool, err error) {
+	for _, s := range reloadableSources(w.opts.Source) {
+		ok, err := s.Reload()
+		if err != nil {
+			return false, err
+		}
+		changed = changed || ok
+	}
+	return changed, nil
+}
+
+func (w *Watcher[T]) reload() error {
+	w.mu.Lock()
+
+	conf, err := w.load()
+	if err != nil {
+		w.mu.Unlock()
+		return err
+	}
+
+	old := w.current.Load()
+
+	changed := newParsers(w.opts.Parsers).changedFields(reflect.ValueOf(old).Elem(), reflect.ValueOf(conf).Elem(), "", nil)
+	if len(changed) == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+
+	w.current.Store(conf)
+
+	// The subscribers are notified without the lock so a slow one doesn't block the other
+	// reloads and one can subscribe or reload without deadlocking.
+	subscribers := slices.Clone(w.subscribers)
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(old, conf, changed)
+	}
+
+	return nil
+}
+
+func (w *Watcher[T]) load() (*T, error) {
+	conf := new(T)
+	if err := InitWithOptions(conf, w.opts); err != nil {
+		return nil, err
+	}
+
+	if v, ok := any(conf).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return conf, nil
+}
+
+// changedFields appends to res the paths of the fields which differ between a and b.
+// The values of the types of p are compared as a whole.
+func (p parsers) changedFields(a, b reflect.Value, path string, res []string) []string {
+	switch {
+	case a.Kind() == reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				res = append(res, path)
+			}
+			return res
+		}
+		return p.changedFields(a.Elem(), b.Elem(), path, res)
+
+	case a.Kind() == reflect.Struct && !p.isValueType(a.Type()) && hasExportedFields(a.Type()):
+		// unexported fields are never read by envconfig so they can't change
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			res = p.changedFields(a.Field(i), b.Field(i), combineName(path, field.Name), res)
+		}
+		return res
+
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			res = append(res, path)
+		}
+		return res
+	}
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package envconfig_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vrischmann/envconfig"
+)
+
+type watchedConfig struct {
+	Log struct {
+		Level string
+	}
+	RateLimit int
+}
+
+func (c *watchedConfig) Validate() error {
+	if c.RateLimit < 0 {
+		return errors.New("rate limit must be positive")
+	}
+	return nil
+}
+
+func TestWatcherReload(t *testing.T) {
+	src := envconfig.MapSource{
+		"LOG_LEVEL":  "info",
+		"RATE_LIMIT": "10",
+	}
+
+	w, err := envconfig.NewWatcher[watchedConfig](envconfig.Options{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, "info", w.Get().Log.Level)
+
+	var (
+		calls   int
+		oldConf *watchedConfig
+		newConf *watchedConfig
+		changed []string
+	)
+	w.Subscribe(func(o, n *watchedConfig, c []string) {
+		calls++
+		oldConf, newConf, changed = o, n, c
+	})
+
+	// nothing changed
+	require.NoError(t, w.Reload())
+	require.Equal(t, 0, calls)
+
+	src["LOG_LEVEL"] = "debug"
+	require.NoError(t, w.Reload())
+	require.Equal(t, 1, calls)
+	require.Equal(t, "info", oldConf.Log.Level)
+	require.Equal(t, "debug", newConf.Log.Level)
+	require.Equal(t, []string{"Log.Level"}, changed)
+	require.Equal(t, "debug", w.Get().Log.Level)
+
+	// invalid values are never swapped in
+	src["RATE_LIMIT"] = "-1"
+	require.Error(t, w.Reload())
+	src["RATE_LIMIT"] = "foobar"
+	require.Error(t, w.Reload())
+	require.Equal(t, 1, calls)
+	require.Equal(t, 10, w.Get().RateLimit)
+}
+
+func TestWatcherSubscribeFromSubscriber(t *testing.T) {
+	src := envconfig.MapSource{
+		"LOG_LEVEL":  "info",
+		"RATE_LIMIT": "10",
+	}
+
+	w, err := envconfig.NewWatcher[watchedConfig](envconfig.Options{Source: src})
+	require.NoError(t, err)
+
+	var calls int
+	w.Subscribe(func(_, _ *watchedConfig, _ []string) {
+		w.Subscribe(func(_, _ *watchedConfig, _ []string) {
+			calls++
+		})
+	})
+
+	src["LOG_LEVEL"] = "debug"
+	require.NoError(t, w.Reload())
+	require.Equal(t, 0, calls)
+
+	src["LOG_LEVEL"] = "warning"
+	require.NoError(t, w.Reload())
+	require.Equal(t, 1, calls)
+}
+
+func TestWatcherInitialLoadError(t *testing.T) {
+	_, err := envconfig.NewWatcher[watchedConfig](envconfig.Options{Source: envconfig.MapSource{}})
+	require.Error(t, err)
+}
+
+func TestWatcherFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.env")
+	require.NoError(t, os.WriteFile(path, []byte("# comment\nLOG_LEVEL=info\nexport RATE_LIMIT=\"10\"\n"), 0644))
+
+	fs, err := envconfig.NewFileSource(path)
+	require.NoError(t, err)
+
+	w, err := envconfig.NewWatcher[watchedConfig](envconfig.Options{
+		Source: envconfig.MultiSource{envconfig.MapSource{"RATE_LIMIT": "20"}, fs},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "info", w.Get().Log.Level)
+	require.Equal(t, 20, w.Get().RateLimit)
+
+	changedCh := make(chan []string, 1)
+	w.Subscribe(func(_, _ *watchedConfig, changed []string) {
+		changedCh <- changed
+	})
+
+	done := make(chan struct{})
+	defer close(done)
+	go w.Watch(done, 10*time.Millisecond, nil)
+
+	require.NoError(t, os.WriteFile(path, []byte("LOG_LEVEL=warning\nRATE_LIMIT=10\n"), 0644))
+
+	select {
+	case changed := <-changedCh:
+		require.Equal(t, []string{"Log.Level"}, changed)
+		require.Equal(t, "warning", w.Get().Log.Level)
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration was not reloaded")
+	}
+}
@@ -0,0 +1,135 @@
+package envconfig
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// CommonInitialisms are the initialisms known by DefaultWordSplitter. It's golint's list plus the mixed case
+// IPv4, IPv6 and OAuth, which would otherwise be split like I, Pv4.
+var CommonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON",
+	"LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID",
+	"UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+	"IPv4", "IPv6", "OAuth",
+}
+
+// DefaultWordSplitter is the WordSplitter used by the built-in NameMappers, it knows CommonInitialisms.
+var DefaultWordSplitter = NewWordSplitter(CommonInitialisms...)
+
+// WordSplitter splits field names into words, for example HTTPSProxyURL into HTTPS, Proxy and URL.
+//
+// The rules are:
+//   - an upper case letter followed by lower case letters is a word, like Proxy
+//   - a run of upper case letters is a word, except its last letter if it's followed by a lower case letter,
+//     like HTTPS in HTTPSProxy
+//   - digits belong to the word before them, like S3 in S3Bucket or Http2 in Http2Enabled
+//   - an underscore is a word boundary
+//   - a known initialism is a word, optionally followed by digits or a plural s, like IDs in UserIDs
+//
+// Initialisms are matched case sensitively so mixed case ones like OAuth can be used: with CommonInitialisms,
+// OAuth2Token is split into OAuth2 and Token, without them into O, Auth2 and Token.
+type WordSplitter struct {
+	initialisms [][]rune // longest first
+}
+
+// NewWordSplitter creates a WordSplitter knowing the initialisms.
+func NewWordSplitter(initialisms ...string) *WordSplitter {
+	s := &WordSplitter{}
+	for _, v := range initialisms {
+		if v != "" {
+			s.initialisms = append(s.initialisms, []rune(v))
+		}
+	}
+	sort.SliceStable(s.initialisms, func(i, j int) bool {
+		return len(s.initialisms[i]) > len(s.initialisms[j])
+	})
+
+	return s
+}
+
+// Split splits name into words.
+func (s *WordSplitter) Split(name string) []string {
+	var (
+		words []string
+		n     = []rune(name)
+	)
+
+	for i := 0; i < len(n); {
+		if n[i] == '_' {
+			i++
+			continue
+		}
+
+		end := s.matchInitialism(n, i)
+		if end < 0 {
+			end = wordEnd(n, i)
+		}
+		for end < len(n) && unicode.IsDigit(n[end]) {
+			end++
+		}
+
+		words = append(words, string(n[i:end]))
+		i = end
+	}
+
+	return words
+}
+
+// matchInitialism returns the end of the initialism starting at i, or -1 if there's none.
+func (s *WordSplitter) matchInitialism(n []rune, i int) int {
+	for _, initialism := range s.initialisms {
+		end := i + len(initialism)
+		if end > len(n) || string(n[i:end]) != string(initialism) {
+			continue
+		}
+
+		// plural
+		if end < len(n) && n[end] == 's' && isWordStart(n, end+1) {
+			end++
+		}
+		if isWordStart(n, end) {
+			return end
+		}
+	}
+
+	return -1
+}
+
+// isWordStart returns true if a word can start at i, or if it's the end of the name.
+func isWordStart(n []rune, i int) bool {
+	return i >= len(n) || unicode.IsUpper(n[i]) || unicode.IsDigit(n[i]) || n[i] == '_'
+}
+
+// wordEnd returns the end of the word starting at i, not counting the digits following it.
+func wordEnd(n []rune, i int) int {
+	j := i + 1
+
+	switch {
+	case unicode.IsDigit(n[i]):
+		return i
+
+	case unicode.IsUpper(n[i]) && j < len(n) && unicode.IsUpper(n[j]):
+		for j < len(n) && unicode.IsUpper(n[j]) && !(j+1 < len(n) && unicode.IsLower(n[j+1])) {
+			j++
+		}
+		return j
+
+	default:
+		for j < len(n) && !isWordStart(n, j) {
+			j++
+		}
+		return j
+	}
+}
+
+// snakeCase returns the words of each element of path joined by underscores.
+func (s *WordSplitter) snakeCase(path []string) string {
+	var words []string
+	for _, elem := range path {
+		words = append(words, s.Split(elem)...)
+	}
+
+	return strings.Join(words, "_")
+}